@@ -25,6 +25,9 @@ func main() {
 		modelsOnly    = flag.Bool("models-only", false, "Generate only models")
 		clientOnly    = flag.Bool("client-only", false, "Generate only client")
 		embedClient   = flag.Bool("embed-client", false, "Copy client packages instead of importing from library")
+		validation    = flag.Bool("validation", false, "Generate Validate methods checking schema constraints")
+		groupByTag    = flag.Bool("group-by-tag", false, "Generate the operations of each tag on a sub-client of its own")
+		convertOnly   = flag.Bool("convert-only", false, "Write the spec converted to OpenAPI 3.0 to stdout instead of generating code")
 		verbose       = flag.Bool("verbose", false, "Enable verbose output")
 		showVersion   = flag.Bool("version", false, "Show version information")
 	)
@@ -43,6 +46,8 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -spec api.yaml -package myapi -output ./myapi -templates ./templates\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  # Use custom client import path\n")
 		fmt.Fprintf(os.Stderr, "  %s -spec api.yaml -package myapi -output ./myapi -client-import github.com/myorg/myclient\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  # Convert a Swagger 2.0 or OpenAPI 3.1 spec to OpenAPI 3.0\n")
+		fmt.Fprintf(os.Stderr, "  %s -spec swagger.json -convert-only > openapi.yaml\n\n", os.Args[0])
 	}
 
 	flag.Parse()
@@ -59,6 +64,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *convertOnly {
+		converted, err := gen.ConvertSpec(*specPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to convert specification: %v\n", err)
+			os.Exit(1)
+		}
+		if _, err := os.Stdout.Write(converted); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write converted specification: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if *packageName == "" {
 		fmt.Fprintf(os.Stderr, "Error: -package flag is required\n\n")
 		flag.Usage()
@@ -90,18 +108,20 @@ func main() {
 
 	// Create configuration
 	config := &gen.Config{
-		SpecPath:       absSpecPath,
-		OutputDir:      absOutputDir,
-		PackageName:    *packageName,
-		ClientName:     *clientName,
-		TemplateDir:    *templateDir,
-		ModelPackage:   *modelPackage,
-		ClientPackage:  *clientPackage,
-		ClientImport:   *clientImport,
-		GenerateModels: generateModels,
-		GenerateClient: generateClient,
-		EmbedClient:    *embedClient,
-		Verbose:        *verbose,
+		SpecPath:           absSpecPath,
+		OutputDir:          absOutputDir,
+		PackageName:        *packageName,
+		ClientName:         *clientName,
+		TemplateDir:        *templateDir,
+		ModelPackage:       *modelPackage,
+		ClientPackage:      *clientPackage,
+		ClientImport:       *clientImport,
+		GenerateModels:     generateModels,
+		GenerateClient:     generateClient,
+		EmbedClient:        *embedClient,
+		GenerateValidation: *validation,
+		GroupByTag:         *groupByTag,
+		Verbose:            *verbose,
 	}
 
 	// Create generator
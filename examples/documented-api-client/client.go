@@ -3,8 +3,9 @@ package productapi
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/jmcarbo/oapix/pkg/client"
+	"strings"
 )
 
 // Client is the client for the API
@@ -29,94 +30,264 @@ func NewClient(config *client.Config) (*Client, error) {
 // Retrieves a paginated list of all products in the catalog.
 // The results can be filtered by various criteria including
 // category, price range, and availability status.
-func (c *Client) ListProducts(ctx context.Context, params *ListProductsParams) (*client.MultiResponse, error) {
-	path := fmt.Sprintf("/products")
-
-	opts := []client.RequestOption{}
-
-	// Add query parameters
-
-	if params != nil {
-		opts = append(opts, client.WithQueryParam("page", fmt.Sprintf("%v", params.Page)))
+//
+// Possible responses:
+//   - 200: A page of products (ListProductsResponse)
+//   - 400: Invalid parameters (Error)
+//
+// Use ListProductsRaw to access the undecoded response.
+func (c *Client) ListProducts(ctx context.Context, params *ListProductsParams) (*ListProductsResponse, error) {
+	resp, err := c.ListProductsRaw(ctx, params)
+	if err != nil {
+		return nil, err
 	}
 
-	if params != nil {
-		opts = append(opts, client.WithQueryParam("limit", fmt.Sprintf("%v", params.Limit)))
+	var result ListProductsResponse
+	if err := resp.As(&result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
+
+// ListProductsRaw performs a GET request to /products and returns the undecoded response
+func (c *Client) ListProductsRaw(ctx context.Context, params *ListProductsParams) (*client.MultiResponse, error) {
+	path := "/products"
 
+	opts := []client.RequestOption{}
+
+	// Add optional query, header and cookie parameters that are set
 	if params != nil {
-		opts = append(opts, client.WithQueryParam("category", fmt.Sprintf("%v", params.Category)))
+		if params.Page != nil {
+			opts = append(opts, client.WithQueryValues(client.QueryParam("page", *params.Page, client.StyleForm, true)))
+		}
+		if params.Limit != nil {
+			opts = append(opts, client.WithQueryValues(client.QueryParam("limit", *params.Limit, client.StyleForm, true)))
+		}
+		if params.Category != nil {
+			opts = append(opts, client.WithQueryValues(client.QueryParam("category", *params.Category, client.StyleForm, true)))
+		}
 	}
 
 	resp, err := c.Request(ctx, "GET", path, nil, opts...)
 
 	if err != nil {
-		return nil, err
+		return nil, decodeListProductsError(err)
 	}
 
 	return &client.MultiResponse{Response: *resp}, nil
 }
 
-// ListProductsParams contains optional parameters for ListProducts
+// ListProductsParams contains optional parameters for ListProducts.
+// Parameters left nil are not sent.
 type ListProductsParams struct {
 
 	// Page number for pagination
-	Page int64
+	Page *int64
 
 	// Number of items per page
-	Limit int64
+	Limit *int64
 
 	// Filter by product category
-	Category string
+	Category *string
+}
+
+// ListProductsError is returned by ListProducts when the API responds with one of its
+// declared error statuses. Exactly one of the Status fields is set, matching the
+// response status. Undeclared statuses are returned as *client.APIError.
+type ListProductsError struct {
+	*client.APIError
+
+	// Status400 holds the decoded 400 response: Invalid parameters
+	Status400 *Error
+}
+
+// Unwrap returns the underlying *client.APIError
+func (e *ListProductsError) Unwrap() error {
+	return e.APIError
+}
+
+// decodeListProductsError converts declared error responses into *ListProductsError
+func decodeListProductsError(err error) error {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	typed := &ListProductsError{APIError: apiErr}
+	switch {
+	case apiErr.StatusCode == 400:
+		typed.Status400 = new(Error)
+		if decodeErr := apiErr.DecodeBody(typed.Status400); decodeErr != nil {
+			return err
+		}
+	default:
+		return err
+	}
+	return typed
 }
 
 // CreateProduct performs a POST request to /products
 // Create a new product
 // Creates a new product in the catalog.
 // Requires admin authentication.
-func (c *Client) CreateProduct(ctx context.Context, req CreateProductRequest) (*client.MultiResponse, error) {
-	path := fmt.Sprintf("/products")
+//
+// Possible responses:
+//   - 201: Product created (Product)
+//   - 400: Invalid product (Error)
+//
+// Use CreateProductRaw to access the undecoded response.
+func (c *Client) CreateProduct(ctx context.Context, req CreateProductRequest) (*Product, error) {
+	resp, err := c.CreateProductRaw(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Product
+	if err := resp.As(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateProductRaw performs a POST request to /products and returns the undecoded response
+func (c *Client) CreateProductRaw(ctx context.Context, req CreateProductRequest) (*client.MultiResponse, error) {
+	path := "/products"
 
 	resp, err := c.RequestJSON(ctx, "POST", path, req)
 
 	if err != nil {
-		return nil, err
+		return nil, decodeCreateProductError(err)
 	}
 
 	return &client.MultiResponse{Response: *resp}, nil
 }
 
-// GetProductsReviews performs a GET request to /products/{productId}/reviews
-// List product reviews
-func (c *Client) GetProductsReviews(ctx context.Context, productId string) (*client.MultiResponse, error) {
-	path := fmt.Sprintf("/products/%s/reviews", productId)
+// CreateProductError is returned by CreateProduct when the API responds with one of its
+// declared error statuses. Exactly one of the Status fields is set, matching the
+// response status. Undeclared statuses are returned as *client.APIError.
+type CreateProductError struct {
+	*client.APIError
 
-	resp, err := c.Request(ctx, "GET", path, nil)
+	// Status400 holds the decoded 400 response: Invalid product
+	Status400 *Error
+}
 
-	if err != nil {
-		return nil, err
+// Unwrap returns the underlying *client.APIError
+func (e *CreateProductError) Unwrap() error {
+	return e.APIError
+}
+
+// decodeCreateProductError converts declared error responses into *CreateProductError
+func decodeCreateProductError(err error) error {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err
 	}
 
-	return &client.MultiResponse{Response: *resp}, nil
+	typed := &CreateProductError{APIError: apiErr}
+	switch {
+	case apiErr.StatusCode == 400:
+		typed.Status400 = new(Error)
+		if decodeErr := apiErr.DecodeBody(typed.Status400); decodeErr != nil {
+			return err
+		}
+	default:
+		return err
+	}
+	return typed
 }
 
 // GetProduct performs a GET request to /products/{productId}
 // Get product by ID
-func (c *Client) GetProduct(ctx context.Context, productId string) (*client.MultiResponse, error) {
-	path := fmt.Sprintf("/products/%s", productId)
+//
+// Possible responses:
+//   - 200: The product (Product)
+//   - 404: Product not found (Error)
+//
+// Use GetProductRaw to access the undecoded response.
+func (c *Client) GetProduct(ctx context.Context, productId string) (*Product, error) {
+	resp, err := c.GetProductRaw(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Product
+	if err := resp.As(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetProductRaw performs a GET request to /products/{productId} and returns the undecoded response
+func (c *Client) GetProductRaw(ctx context.Context, productId string) (*client.MultiResponse, error) {
+	path := strings.ReplaceAll("/products/{productId}", "{productId}", client.PathParam("productId", productId, client.StyleSimple, false))
 
 	resp, err := c.Request(ctx, "GET", path, nil)
 
 	if err != nil {
-		return nil, err
+		return nil, decodeGetProductError(err)
 	}
 
 	return &client.MultiResponse{Response: *resp}, nil
 }
 
+// GetProductError is returned by GetProduct when the API responds with one of its
+// declared error statuses. Exactly one of the Status fields is set, matching the
+// response status. Undeclared statuses are returned as *client.APIError.
+type GetProductError struct {
+	*client.APIError
+
+	// Status404 holds the decoded 404 response: Product not found
+	Status404 *Error
+}
+
+// Unwrap returns the underlying *client.APIError
+func (e *GetProductError) Unwrap() error {
+	return e.APIError
+}
+
+// decodeGetProductError converts declared error responses into *GetProductError
+func decodeGetProductError(err error) error {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	typed := &GetProductError{APIError: apiErr}
+	switch {
+	case apiErr.StatusCode == 404:
+		typed.Status404 = new(Error)
+		if decodeErr := apiErr.DecodeBody(typed.Status404); decodeErr != nil {
+			return err
+		}
+	default:
+		return err
+	}
+	return typed
+}
+
 // UpdateProduct performs a PUT request to /products/{productId}
-func (c *Client) UpdateProduct(ctx context.Context, productId string, req UpdateProductRequest) (*client.MultiResponse, error) {
-	path := fmt.Sprintf("/products/%s", productId)
+//
+// Possible responses:
+//   - 200: The updated product (Product)
+//
+// Use UpdateProductRaw to access the undecoded response.
+func (c *Client) UpdateProduct(ctx context.Context, productId string, req UpdateProductRequest) (*Product, error) {
+	resp, err := c.UpdateProductRaw(ctx, productId, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Product
+	if err := resp.As(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateProductRaw performs a PUT request to /products/{productId} and returns the undecoded response
+func (c *Client) UpdateProductRaw(ctx context.Context, productId string, req UpdateProductRequest) (*client.MultiResponse, error) {
+	path := strings.ReplaceAll("/products/{productId}", "{productId}", client.PathParam("productId", productId, client.StyleSimple, false))
 
 	resp, err := c.RequestJSON(ctx, "PUT", path, req)
 
@@ -130,8 +301,19 @@ func (c *Client) UpdateProduct(ctx context.Context, productId string, req Update
 // DeleteProduct performs a DELETE request to /products/{productId}
 // Delete a product
 // Permanently removes a product from the catalog
-func (c *Client) DeleteProduct(ctx context.Context, productId string) (*client.MultiResponse, error) {
-	path := fmt.Sprintf("/products/%s", productId)
+//
+// Possible responses:
+//   - 204: Product deleted
+//
+// Use DeleteProductRaw to access the undecoded response.
+func (c *Client) DeleteProduct(ctx context.Context, productId string) error {
+	_, err := c.DeleteProductRaw(ctx, productId)
+	return err
+}
+
+// DeleteProductRaw performs a DELETE request to /products/{productId} and returns the undecoded response
+func (c *Client) DeleteProductRaw(ctx context.Context, productId string) (*client.MultiResponse, error) {
+	path := strings.ReplaceAll("/products/{productId}", "{productId}", client.PathParam("productId", productId, client.StyleSimple, false))
 
 	resp, err := c.Request(ctx, "DELETE", path, nil)
 
@@ -141,3 +323,36 @@ func (c *Client) DeleteProduct(ctx context.Context, productId string) (*client.M
 
 	return &client.MultiResponse{Response: *resp}, nil
 }
+
+// GetProductsReviews performs a GET request to /products/{productId}/reviews
+// List product reviews
+//
+// Possible responses:
+//   - 200: Reviews of the product ([]Review)
+//
+// Use GetProductsReviewsRaw to access the undecoded response.
+func (c *Client) GetProductsReviews(ctx context.Context, productId string) ([]Review, error) {
+	resp, err := c.GetProductsReviewsRaw(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Review
+	if err := resp.As(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetProductsReviewsRaw performs a GET request to /products/{productId}/reviews and returns the undecoded response
+func (c *Client) GetProductsReviewsRaw(ctx context.Context, productId string) (*client.MultiResponse, error) {
+	path := strings.ReplaceAll("/products/{productId}/reviews", "{productId}", client.PathParam("productId", productId, client.StyleSimple, false))
+
+	resp, err := c.Request(ctx, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.MultiResponse{Response: *resp}, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -44,8 +45,8 @@ func handleCreateAsset(ctx context.Context, client *APIClient) {
 		Type: "document",
 	}
 
-	// The method returns a MultiResponse
-	resp, err := client.CreateAsset(ctx, "asset123", assetInput)
+	// The Raw variant returns the undecoded MultiResponse
+	resp, err := client.CreateAssetRaw(ctx, "asset123", assetInput)
 	if err != nil {
 		// Network or request building errors
 		log.Printf("Request failed: %v", err)
@@ -103,39 +104,25 @@ func handleCreateAsset(ctx context.Context, client *APIClient) {
 	}
 }
 
-func handleGetUser(ctx context.Context, client *APIClient) {
-	resp, err := client.GetUser(ctx, "user123")
+func handleGetUser(ctx context.Context, apiClient *APIClient) {
+	// Single-success operations return the decoded model directly
+	user, err := apiClient.GetUser(ctx, "user123")
 	if err != nil {
-		log.Printf("Request failed: %v", err)
-		return
-	}
-
-	// Use helper methods for common checks
-	if resp.IsSuccess() {
-		var user User
-		if err := resp.As(&user); err != nil {
-			log.Printf("Failed to parse user: %v", err)
-			return
-		}
-		fmt.Printf("User found: %s <%s>\n", user.Name, user.Email)
-	} else if resp.Is(404) {
-		var notFound NotFoundError
-		if err := resp.As(&notFound); err != nil {
-			log.Printf("Failed to parse not found error: %v", err)
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			fmt.Printf("User not found: %s\n", apiErr.Message)
 			return
 		}
-		fmt.Printf("User not found: %s\n", notFound.Message)
-	} else if resp.IsError() {
-		// Handle any other error
-		fmt.Printf("Error response: %d\n", resp.StatusCode)
+		log.Printf("Request failed: %v", err)
+		return
 	}
+	fmt.Printf("User found: %s <%s>\n", user.Name, user.Email)
 }
 
 func handleWithTypeSafeHelpers(ctx context.Context, client *APIClient) {
-	// When HasMultipleSuccessResponses is true, the generator creates
-	// type-safe helper methods for each response type
-
-	resp, err := client.CreateAsset(ctx, "asset456", AssetInput{
+	// When HasMultipleSuccessResponses is true, the generated method returns
+	// a wrapper with type-safe helper methods for each response type
+	assetResp, err := client.CreateAsset(ctx, "asset456", AssetInput{
 		Name: "Another Asset",
 		Type: "image",
 	})
@@ -144,9 +131,6 @@ func handleWithTypeSafeHelpers(ctx context.Context, client *APIClient) {
 		return
 	}
 
-	// Wrap the response with type-safe helpers
-	assetResp := WrapCreateAssetResponse(resp)
-
 	// Now you can use type-safe methods
 	if assetResp.Is(200) {
 		asset, err := assetResp.As200() // Returns *ContentNodeTransport
@@ -180,12 +164,32 @@ func NewAPIClient(config *client.Config) (*APIClient, error) {
 }
 
 // These method signatures demonstrate what oapix-gen would generate
-func (c *APIClient) CreateAsset(ctx context.Context, assetId string, req AssetInput) (*client.MultiResponse, error) {
+func (c *APIClient) CreateAsset(ctx context.Context, assetId string, req AssetInput) (*CreateAssetResponseWrapper, error) {
+	resp, err := c.CreateAssetRaw(ctx, assetId, req)
+	if err != nil {
+		return nil, err
+	}
+	return WrapCreateAssetResponse(resp), nil
+}
+
+func (c *APIClient) CreateAssetRaw(ctx context.Context, assetId string, req AssetInput) (*client.MultiResponse, error) {
 	// Implementation would be generated
 	return nil, nil
 }
 
-func (c *APIClient) GetUser(ctx context.Context, userId string) (*client.MultiResponse, error) {
+func (c *APIClient) GetUser(ctx context.Context, userId string) (*User, error) {
+	resp, err := c.GetUserRaw(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	var result User
+	if err := resp.As(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *APIClient) GetUserRaw(ctx context.Context, userId string) (*client.MultiResponse, error) {
 	// Implementation would be generated
 	return nil, nil
 }
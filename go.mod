@@ -2,10 +2,13 @@ module github.com/jmcarbo/oapix
 
 go 1.24
 
-require golang.org/x/net v0.19.0
+require (
+	github.com/getkin/kin-openapi v0.132.0
+	golang.org/x/net v0.19.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	github.com/getkin/kin-openapi v0.132.0 // indirect
 	github.com/go-openapi/jsonpointer v0.21.0 // indirect
 	github.com/go-openapi/swag v0.23.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
@@ -14,5 +17,4 @@ require (
 	github.com/oasdiff/yaml v0.0.0-20250309154309-f31be36b4037 // indirect
 	github.com/oasdiff/yaml3 v0.0.0-20250309153720-d2182401db90 // indirect
 	github.com/perimeterx/marshmallow v1.1.5 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -8,22 +8,38 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // BaseClient implements the base functionality for API clients
 type BaseClient struct {
-	httpClient     HTTPClient
-	baseURL        string
-	apiKey         string
-	requestEditors []RequestEditor
+	httpClient HTTPClient
+	baseURL    string
+	// baseURLSet is set when the base URL was configured rather than taken
+	// from Servers, and then takes precedence over operation servers
+	baseURLSet bool
+	// serverVariables set the variables of server URLs
+	serverVariables map[string]string
+	apiKey          string
+	requestEditors  []RequestEditor
+	// validateRequests enables validation of request bodies before sending
+	validateRequests bool
+	// securitySchemes describe how credentials are sent, by scheme name
+	securitySchemes map[string]SecurityScheme
+	credentials     map[string]Credential
+	credentialsMu   sync.RWMutex
 }
 
 // Config holds configuration for creating a new client
 type Config struct {
 	// BaseURL is the base URL of the API
 	BaseURL string
-	// APIKey for authentication (optional)
+	// APIKey for authentication (optional). Requests with security
+	// requirements send it for header and query API key schemes and bearer
+	// schemes without a credential of their own; other requests send it as
+	// a bearer token.
 	APIKey string
 	// HTTPClient is a custom HTTP client (optional)
 	HTTPClient HTTPClient
@@ -31,17 +47,40 @@ type Config struct {
 	TransportConfig *TransportConfig
 	// RequestEditors are applied to all requests
 	RequestEditors []RequestEditor
+	// ValidateRequests makes RequestJSON validate bodies implementing Validator
+	// before sending them, failing locally instead of with a 400 response
+	ValidateRequests bool
+	// Servers are the servers of the API, which generated clients set from
+	// the spec. Without a BaseURL, requests are sent to the selected server.
+	Servers []Server
+	// Server selects a server of Servers by name or by index, such as "1",
+	// defaulting to the first
+	Server string
+	// ServerVariables set the variables of the selected server's URL, which
+	// otherwise take their defaults
+	ServerVariables map[string]string
+	// SecuritySchemes describe how the credentials of the API's security
+	// schemes are sent, by scheme name. Generated clients set them from the spec.
+	SecuritySchemes map[string]SecurityScheme
 }
 
 // NewBaseClient creates a new base client with the given configuration
 func NewBaseClient(config *Config) (*BaseClient, error) {
-	if config.BaseURL == "" {
+	baseURL := config.BaseURL
+	if baseURL == "" && len(config.Servers) > 0 {
+		serverURL, err := serverBaseURL(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select server: %w", err)
+		}
+		baseURL = serverURL
+	}
+	if baseURL == "" {
 		return nil, fmt.Errorf("base URL is required")
 	}
 
 	// Ensure base URL ends with /
-	if !strings.HasSuffix(config.BaseURL, "/") {
-		config.BaseURL += "/"
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
 	}
 
 	// Use provided HTTP client or create a new one
@@ -55,15 +94,59 @@ func NewBaseClient(config *Config) (*BaseClient, error) {
 	}
 
 	return &BaseClient{
-		httpClient:     httpClient,
-		baseURL:        config.BaseURL,
-		apiKey:         config.APIKey,
-		requestEditors: config.RequestEditors,
+		httpClient:       httpClient,
+		baseURL:          baseURL,
+		baseURLSet:       config.BaseURL != "",
+		serverVariables:  config.ServerVariables,
+		apiKey:           config.APIKey,
+		requestEditors:   config.RequestEditors,
+		validateRequests: config.ValidateRequests,
+		securitySchemes:  config.SecuritySchemes,
 	}, nil
 }
 
 // Request makes an HTTP request with the given parameters
 func (c *BaseClient) Request(ctx context.Context, method, path string, body io.Reader, opts ...RequestOption) (*Response, error) {
+	resp, err := c.send(ctx, method, path, body, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Create response
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       respBody,
+	}
+
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		return response, c.parseError(response)
+	}
+
+	return response, nil
+}
+
+// send builds and sends a request, returning the response with its body unread
+func (c *BaseClient) send(ctx context.Context, method, path string, body io.Reader, opts []RequestOption) (*http.Response, error) {
+	// Close bodies that are closers when the request isn't sent, as the HTTP
+	// client does once it is, so streaming bodies stop producing
+	sent := false
+	defer func() {
+		if closer, ok := body.(io.Closer); ok && !sent {
+			_ = closer.Close()
+		}
+	}()
+
 	// Apply request options
 	config := &RequestConfig{}
 	for _, opt := range opts {
@@ -71,7 +154,7 @@ func (c *BaseClient) Request(ctx context.Context, method, path string, body io.R
 	}
 
 	// Build full URL
-	fullURL, err := c.buildURL(path, config.QueryParams)
+	fullURL, err := c.buildURL(path, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
@@ -83,15 +166,24 @@ func (c *BaseClient) Request(ctx context.Context, method, path string, body io.R
 	}
 
 	// Set default headers
-	req.Header.Set("Accept", "application/json")
+	if config.Accept != "" {
+		req.Header.Set("Accept", config.Accept)
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
 	if config.ContentType != "" {
 		req.Header.Set("Content-Type", config.ContentType)
 	} else if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Set API key if provided
-	if c.apiKey != "" {
+	// Send the credentials the request's security requirements call for,
+	// or else the API key as a bearer token
+	if config.Security != nil {
+		if err := c.authorize(ctx, req, config.Security); err != nil {
+			return nil, err
+		}
+	} else if c.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	}
 
@@ -107,34 +199,18 @@ func (c *BaseClient) Request(ctx context.Context, method, path string, body io.R
 		}
 	}
 
+	// Add cookies, keeping any set by the headers or request editors
+	if len(config.Cookies) > 0 {
+		addCookies(req, config.Cookies)
+	}
+
 	// Make request
+	sent = true
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Create response
-	response := &Response{
-		StatusCode: resp.StatusCode,
-		Headers:    resp.Header,
-		Body:       respBody,
-	}
-
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return response, c.parseError(response)
-	}
-
-	return response, nil
+	return resp, nil
 }
 
 // BaseURL returns the base URL of the API
@@ -148,6 +224,7 @@ func (c *BaseClient) SetBaseURL(baseURL string) {
 		baseURL += "/"
 	}
 	c.baseURL = baseURL
+	c.baseURLSet = true
 }
 
 // AddRequestEditor adds a new request editor to the client
@@ -160,8 +237,28 @@ func (c *BaseClient) SetRequestEditors(editors []RequestEditor) {
 	c.requestEditors = editors
 }
 
+// addCookies appends cookies to the request's Cookie header in name order.
+// Unlike http.Request.AddCookie it doesn't quote values containing commas,
+// which the form style uses to separate array items.
+func addCookies(req *http.Request, cookies map[string]string) {
+	names := make([]string, 0, len(cookies))
+	for name := range cookies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names)+1)
+	if existing := req.Header.Get("Cookie"); existing != "" {
+		pairs = append(pairs, existing)
+	}
+	for _, name := range names {
+		pairs = append(pairs, name+"="+cookies[name])
+	}
+	req.Header.Set("Cookie", strings.Join(pairs, "; "))
+}
+
 // buildURL builds the full URL with query parameters
-func (c *BaseClient) buildURL(path string, queryParams map[string]string) (string, error) {
+func (c *BaseClient) buildURL(path string, config *RequestConfig) (string, error) {
 	// Remove leading slash from path if present
 	path = strings.TrimPrefix(path, "/")
 
@@ -171,6 +268,25 @@ func (c *BaseClient) buildURL(path string, queryParams map[string]string) (strin
 		return "", fmt.Errorf("invalid base URL: %w", err)
 	}
 
+	// Use the request's own server, which may be relative to the base URL
+	serverURL := config.BaseURL
+	if serverURL == "" && config.Server != nil {
+		serverURL, err = c.requestServerURL(*config.Server)
+		if err != nil {
+			return "", err
+		}
+	}
+	if serverURL != "" {
+		if !strings.HasSuffix(serverURL, "/") {
+			serverURL += "/"
+		}
+		ref, err := url.Parse(serverURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid request base URL: %w", err)
+		}
+		baseURL = baseURL.ResolveReference(ref)
+	}
+
 	// Parse path
 	pathURL, err := url.Parse(path)
 	if err != nil {
@@ -181,11 +297,16 @@ func (c *BaseClient) buildURL(path string, queryParams map[string]string) (strin
 	fullURL := baseURL.ResolveReference(pathURL)
 
 	// Add query parameters
-	if len(queryParams) > 0 {
+	if len(config.QueryParams) > 0 || len(config.QueryValues) > 0 {
 		q := fullURL.Query()
-		for k, v := range queryParams {
+		for k, v := range config.QueryParams {
 			q.Set(k, v)
 		}
+		for k, vs := range config.QueryValues {
+			for _, v := range vs {
+				q.Add(k, v)
+			}
+		}
 		fullURL.RawQuery = q.Encode()
 	}
 
@@ -197,6 +318,8 @@ func (c *BaseClient) parseError(resp *Response) error {
 	apiError := &APIError{
 		StatusCode: resp.StatusCode,
 		Message:    fmt.Sprintf("API error: %d", resp.StatusCode),
+		Headers:    resp.Headers,
+		Body:       resp.Body,
 	}
 
 	// Try to parse JSON error response
@@ -210,6 +333,11 @@ func (c *BaseClient) parseError(resp *Response) error {
 				apiError.Message = msg
 			} else if msg, ok := errorData["message"].(string); ok {
 				apiError.Message = msg
+			} else if msg, ok := errorData["detail"].(string); ok {
+				// application/problem+json details (RFC 9457)
+				apiError.Message = msg
+			} else if msg, ok := errorData["title"].(string); ok {
+				apiError.Message = msg
 			}
 		} else {
 			// If not JSON, use body as message
@@ -222,21 +350,68 @@ func (c *BaseClient) parseError(resp *Response) error {
 
 // RequestJSON makes a JSON request
 func (c *BaseClient) RequestJSON(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error) {
-	var bodyReader io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		bodyReader = bytes.NewReader(jsonBody)
+	bodyReader, err := c.encodedBody(body, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	// Default the content type to JSON, which options may replace with
+	// another JSON media type such as application/merge-patch+json
+	opts = append([]RequestOption{WithContentType("application/json")}, opts...)
+
+	return c.Request(ctx, method, path, bodyReader, opts...)
+}
+
+// RequestEncoded makes a request whose body is encoded with the codec
+// registered for its content type, as set by WithContentType. Bodies are
+// sent as JSON when no content type is set or no codec is registered for it.
+func (c *BaseClient) RequestEncoded(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error) {
+	config := &RequestConfig{}
+	for _, opt := range opts {
+		opt(config)
 	}
 
-	// Ensure content type is set to JSON
-	opts = append(opts, WithContentType("application/json"))
+	bodyReader, err := c.encodedBody(body, config.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]RequestOption{WithContentType("application/json")}, opts...)
 
 	return c.Request(ctx, method, path, bodyReader, opts...)
 }
 
+// encodedBody validates a request body and encodes it with the codec of the
+// given content type
+func (c *BaseClient) encodedBody(body interface{}, contentType string) (io.Reader, error) {
+	if err := c.validateBody(body); err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	data, err := encodeBody(body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// validateBody validates request bodies implementing Validator when request
+// validation is enabled
+func (c *BaseClient) validateBody(body interface{}) error {
+	if !c.validateRequests {
+		return nil
+	}
+	if v, ok := body.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+	return nil
+}
+
 // ParseJSON parses a JSON response
 func ParseJSON(resp *Response, v interface{}) error {
 	if len(resp.Body) == 0 {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 )
@@ -162,6 +163,25 @@ func TestBaseClient_Request(t *testing.T) {
 			wantStatus: 200,
 			wantErr:    false,
 		},
+		{
+			name:   "request with cookies",
+			method: "GET",
+			path:   "test/cookies",
+			body:   nil,
+			opts: []RequestOption{
+				WithHeader("Cookie", "tracking=1"),
+				WithCookie("session", "abc"),
+				WithCookie("csrf", CookieParam([]string{"x", "y"}, false)),
+			},
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				if got := req.Header.Get("Cookie"); got != "tracking=1; csrf=x,y; session=abc" {
+					t.Errorf("expected Cookie header 'tracking=1; csrf=x,y; session=abc', got '%s'", got)
+				}
+				return mockResponse(200, `{}`), nil
+			},
+			wantStatus: 200,
+			wantErr:    false,
+		},
 		{
 			name:   "error response",
 			method: "GET",
@@ -223,6 +243,7 @@ func TestBaseClient_RequestJSON(t *testing.T) {
 		method   string
 		path     string
 		body     interface{}
+		opts     []RequestOption
 		mockFunc func(req *http.Request) (*http.Response, error)
 		wantErr  bool
 	}{
@@ -267,6 +288,23 @@ func TestBaseClient_RequestJSON(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "declared JSON media types",
+			method: "PATCH",
+			path:   "test/patch",
+			body:   testPayload{Name: "patched"},
+			opts:   []RequestOption{WithContentType("application/merge-patch+json"), WithAccept("application/hal+json")},
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				if got := req.Header.Get("Content-Type"); got != "application/merge-patch+json" {
+					t.Errorf("expected Content-Type application/merge-patch+json, got %s", got)
+				}
+				if got := req.Header.Get("Accept"); got != "application/hal+json" {
+					t.Errorf("expected Accept application/hal+json, got %s", got)
+				}
+				return mockResponse(200, `{}`), nil
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -277,7 +315,7 @@ func TestBaseClient_RequestJSON(t *testing.T) {
 				baseURL:    "https://api.example.com/",
 			}
 
-			_, err := client.RequestJSON(context.Background(), tt.method, tt.path, tt.body)
+			_, err := client.RequestJSON(context.Background(), tt.method, tt.path, tt.body, tt.opts...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RequestJSON() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -349,6 +387,8 @@ func TestBaseClient_buildURL(t *testing.T) {
 		name        string
 		path        string
 		queryParams map[string]string
+		queryValues url.Values
+		baseURL     string
 		want        string
 		wantErr     bool
 	}{
@@ -383,11 +423,44 @@ func TestBaseClient_buildURL(t *testing.T) {
 			want:    "https://api.example.com/v1/users?limit=10&offset=20",
 			wantErr: false,
 		},
+		{
+			name:        "path with escaped segment",
+			path:        "files/" + PathParam("id", "a/b?c", StyleSimple, false),
+			queryParams: nil,
+			want:        "https://api.example.com/v1/files/a%2Fb%3Fc",
+			wantErr:     false,
+		},
+		{
+			name:    "request base URL",
+			path:    "/files",
+			baseURL: "https://uploads.example.com/v2",
+			want:    "https://uploads.example.com/v2/files",
+			wantErr: false,
+		},
+		{
+			name:    "relative request base URL",
+			path:    "/files",
+			baseURL: "/v2",
+			want:    "https://api.example.com/v2/files",
+			wantErr: false,
+		},
+		{
+			name:        "path with repeated query values",
+			path:        "users",
+			queryParams: map[string]string{"limit": "10"},
+			queryValues: url.Values{"status": {"active", "pending"}},
+			want:        "https://api.example.com/v1/users?limit=10&status=active&status=pending",
+			wantErr:     false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := client.buildURL(tt.path, tt.queryParams)
+			got, err := client.buildURL(tt.path, &RequestConfig{
+				BaseURL:     tt.baseURL,
+				QueryParams: tt.queryParams,
+				QueryValues: tt.queryValues,
+			})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildURL() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -449,11 +522,29 @@ func TestRequestOptions(t *testing.T) {
 		t.Errorf("WithQueryParam did not set query param correctly")
 	}
 
+	// Test WithBaseURL
+	WithBaseURL("https://uploads.example.com")(config)
+	if config.BaseURL != "https://uploads.example.com" {
+		t.Errorf("WithBaseURL did not set base URL correctly")
+	}
+
+	// Test WithCookie
+	WithCookie("session", "abc")(config)
+	if config.Cookies["session"] != "abc" {
+		t.Errorf("WithCookie did not set cookie correctly")
+	}
+
 	// Test WithContentType
 	WithContentType("text/plain")(config)
 	if config.ContentType != "text/plain" {
 		t.Errorf("WithContentType did not set content type correctly")
 	}
+
+	// Test WithAccept
+	WithAccept("application/hal+json", "application/problem+json")(config)
+	if config.Accept != "application/hal+json, application/problem+json" {
+		t.Errorf("WithAccept did not set accepted media types correctly")
+	}
 }
 
 func TestBaseClient_parseError(t *testing.T) {
@@ -480,6 +571,14 @@ func TestBaseClient_parseError(t *testing.T) {
 			},
 			wantMessage: "Internal Server Error",
 		},
+		{
+			name: "problem details",
+			resp: &Response{
+				StatusCode: 404,
+				Body:       []byte(`{"type": "about:blank", "title": "Not Found", "detail": "User 42 does not exist"}`),
+			},
+			wantMessage: "User 42 does not exist",
+		},
 		{
 			name: "non-JSON error",
 			resp: &Response{
@@ -512,6 +611,33 @@ func TestBaseClient_parseError(t *testing.T) {
 			if apiErr.StatusCode != tt.resp.StatusCode {
 				t.Errorf("parseError() status code = %v, want %v", apiErr.StatusCode, tt.resp.StatusCode)
 			}
+			if string(apiErr.Body) != string(tt.resp.Body) {
+				t.Errorf("parseError() body = %q, want %q", apiErr.Body, tt.resp.Body)
+			}
 		})
 	}
 }
+
+func TestAPIError_DecodeBody(t *testing.T) {
+	apiErr := &APIError{
+		StatusCode: 422,
+		Body:       []byte(`{"field": "email", "reason": "invalid"}`),
+	}
+
+	var details struct {
+		Field  string `json:"field"`
+		Reason string `json:"reason"`
+	}
+	if err := apiErr.DecodeBody(&details); err != nil {
+		t.Fatalf("DecodeBody() error = %v", err)
+	}
+	if details.Field != "email" || details.Reason != "invalid" {
+		t.Errorf("DecodeBody() = %+v, want field=email reason=invalid", details)
+	}
+
+	// An empty body leaves the target untouched
+	empty := &APIError{StatusCode: 500}
+	if err := empty.DecodeBody(&details); err != nil {
+		t.Errorf("DecodeBody() on empty body error = %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+)
+
+// Codec encodes request bodies and decodes response bodies of a media type
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// EncodedRequester is implemented by clients encoding request bodies with
+// the codec of their content type, such as *BaseClient
+type EncodedRequester interface {
+	// RequestEncoded makes a request whose body is encoded by the codec of its content type
+	RequestEncoded(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error)
+}
+
+var _ EncodedRequester = (*BaseClient)(nil)
+
+// JSONCodec encodes and decodes JSON
+type JSONCodec struct{}
+
+// Marshal encodes v as JSON
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into v
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// XMLCodec encodes and decodes XML
+type XMLCodec struct{}
+
+// Marshal encodes v as XML
+func (XMLCodec) Marshal(v interface{}) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+// Unmarshal decodes XML data into v
+func (XMLCodec) Unmarshal(data []byte, v interface{}) error {
+	return xml.Unmarshal(data, v)
+}
+
+var codecs = struct {
+	sync.RWMutex
+	byMediaType map[string]Codec
+}{
+	byMediaType: map[string]Codec{
+		"application/json": JSONCodec{},
+		"application/xml":  XMLCodec{},
+		"text/xml":         XMLCodec{},
+	},
+}
+
+// RegisterCodec registers the codec of a media type, such as text/csv or
+// application/x-yaml, replacing any codec registered for it before. JSON
+// and XML codecs are registered by default.
+func RegisterCodec(mediaType string, codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.byMediaType[strings.ToLower(mediaType)] = codec
+}
+
+// CodecFor returns the codec registered for a media type, ignoring its
+// parameters. Types without a codec of their own use the codec of their
+// structured syntax suffix, so application/problem+json is decoded as
+// application/json. It returns nil when no codec applies.
+func CodecFor(mediaType string) Codec {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return nil
+	}
+
+	codecs.RLock()
+	defer codecs.RUnlock()
+	if codec, ok := codecs.byMediaType[base]; ok {
+		return codec
+	}
+	if i := strings.LastIndex(base, "+"); i >= 0 {
+		return codecs.byMediaType["application/"+base[i+1:]]
+	}
+	return nil
+}
+
+// Decode decodes a response body into v with the codec of the response's
+// Content-Type, falling back to JSON when it has none
+func Decode(resp *Response, v interface{}) error {
+	if len(resp.Body) == 0 {
+		return nil
+	}
+
+	codec := CodecFor(contentType(resp.Headers))
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	if err := codec.Unmarshal(resp.Body, v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// encodeBody encodes a request body with the codec of the given content
+// type, falling back to JSON when it has none
+func encodeBody(body interface{}, contentType string) ([]byte, error) {
+	codec := CodecFor(contentType)
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	data, err := codec.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	return data, nil
+}
+
+// contentType returns the Content-Type of a response's headers
+func contentType(headers map[string][]string) string {
+	for name, values := range headers {
+		if strings.EqualFold(name, "Content-Type") && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type xmlPet struct {
+	XMLName xml.Name `xml:"pet"`
+	Name    string   `json:"name" xml:"name"`
+}
+
+// lineCodec encodes string slices as lines of text
+type lineCodec struct{}
+
+func (lineCodec) Marshal(v interface{}) ([]byte, error) {
+	return []byte(strings.Join(v.([]string), "\n")), nil
+}
+
+func (lineCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]string) = strings.Split(string(data), "\n")
+	return nil
+}
+
+func TestCodecFor(t *testing.T) {
+	RegisterCodec("text/x-lines", lineCodec{})
+
+	tests := []struct {
+		mediaType string
+		want      Codec
+	}{
+		{mediaType: "application/json", want: JSONCodec{}},
+		{mediaType: "application/json; charset=utf-8", want: JSONCodec{}},
+		{mediaType: "application/problem+json", want: JSONCodec{}},
+		{mediaType: "application/xml", want: XMLCodec{}},
+		{mediaType: "text/xml", want: XMLCodec{}},
+		{mediaType: "application/atom+xml", want: XMLCodec{}},
+		{mediaType: "Text/X-Lines", want: lineCodec{}},
+		{mediaType: "text/csv", want: nil},
+		{mediaType: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := CodecFor(tt.mediaType); got != tt.want {
+			t.Errorf("CodecFor(%q) = %T, want %T", tt.mediaType, got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{name: "JSON", contentType: "application/json", body: `{"name":"rex"}`, want: "rex"},
+		{name: "XML", contentType: "application/xml; charset=utf-8", body: `<pet><name>rex</name></pet>`, want: "rex"},
+		{name: "no content type", body: `{"name":"rex"}`, want: "rex"},
+		{name: "unknown content type", contentType: "text/html", body: `{"name":"rex"}`, want: "rex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{Headers: map[string][]string{}, Body: []byte(tt.body)}
+			if tt.contentType != "" {
+				resp.Headers["Content-Type"] = []string{tt.contentType}
+			}
+			var pet xmlPet
+			if err := Decode(resp, &pet); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if pet.Name != tt.want {
+				t.Errorf("Decode() name = %q, want %q", pet.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseClient_RequestEncoded(t *testing.T) {
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Content-Type"); got != "application/xml" {
+				t.Errorf("expected Content-Type application/xml, got %s", got)
+			}
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != "<pet><name>rex</name></pet>" {
+				t.Errorf("unexpected body %q", body)
+			}
+			resp := mockResponse(400, "<pet><name>taken</name></pet>")
+			resp.Header.Set("Content-Type", "application/xml")
+			return resp, nil
+		}},
+	}
+
+	_, err := client.RequestEncoded(context.Background(), "POST", "pets", xmlPet{Name: "rex"}, WithContentType("application/xml"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("RequestEncoded() error = %v, want *APIError", err)
+	}
+
+	// Error bodies are decoded by their Content-Type too
+	var pet xmlPet
+	if err := apiErr.DecodeBody(&pet); err != nil || pet.Name != "taken" {
+		t.Errorf("DecodeBody() = %+v, %v", pet, err)
+	}
+}
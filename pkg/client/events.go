@@ -0,0 +1,323 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRetry is the delay before reconnecting to an event stream until
+// the server sets another with the retry field
+const defaultRetry = 3 * time.Second
+
+// Event is a server-sent event of a text/event-stream response
+type Event struct {
+	// ID is the id of the event, or the last id the stream set before it
+	ID string
+	// Type is the event type, "message" unless the event names another
+	Type string
+	// Data is the event data, the lines of multi-line data joined by "\n"
+	Data string
+}
+
+// EventReader parses server-sent events from a text/event-stream body
+type EventReader struct {
+	r      *bufio.Reader
+	lastID string
+	// hasID is set once the stream sets an id, which may be empty to reset it
+	hasID bool
+	retry time.Duration
+}
+
+// NewEventReader creates a reader of the events in r
+func NewEventReader(r io.Reader) *EventReader {
+	return &EventReader{r: bufio.NewReader(r)}
+}
+
+// LastEventID returns the last event id the stream set
+func (r *EventReader) LastEventID() string {
+	return r.lastID
+}
+
+// HasLastEventID reports whether the stream set an event id, including an
+// empty one resetting the last event id
+func (r *EventReader) HasLastEventID() bool {
+	return r.hasID
+}
+
+// Retry returns the reconnection delay the stream set with the retry field,
+// or 0 when it set none
+func (r *EventReader) Retry() time.Duration {
+	return r.retry
+}
+
+// Next returns the next event of the stream, skipping comments and blocks
+// without data, or io.EOF once the stream ends. An event cut off by the end
+// of the stream is discarded.
+func (r *EventReader) Next() (*Event, error) {
+	var eventType string
+	var data strings.Builder
+	hasData := false
+
+	for {
+		line, err := r.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		// A blank line dispatches the event
+		if line == "" {
+			if !hasData {
+				eventType = ""
+				continue
+			}
+			event := &Event{ID: r.lastID, Type: eventType, Data: strings.TrimSuffix(data.String(), "\n")}
+			if event.Type == "" {
+				event.Type = "message"
+			}
+			return event, nil
+		}
+
+		// Lines starting with a colon are comments, used to keep connections alive
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				r.lastID = value
+				r.hasID = true
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 32); err == nil {
+				r.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine reads a line ended by CRLF, LF or CR, without its ending
+func (r *EventReader) readLine() (string, error) {
+	var line bytes.Buffer
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case '\n':
+			return line.String(), nil
+		case '\r':
+			if next, err := r.r.Peek(1); err == nil && next[0] == '\n' {
+				_, _ = r.r.ReadByte()
+			}
+			return line.String(), nil
+		}
+		line.WriteByte(b)
+	}
+}
+
+// EventStream reads the server-sent events of a response. When created with
+// WithReconnect, it reconnects after the connection drops, sending the last
+// event id in the Last-Event-ID header. It must be closed once read.
+type EventStream struct {
+	ctx        context.Context
+	connect    func(lastEventID string) (*StreamResponse, error)
+	resp       *StreamResponse
+	reader     *EventReader
+	lastID     string
+	retry      time.Duration
+	reconnects int
+	attempts   int
+}
+
+// Next returns the next event of the stream, or io.EOF once it ends
+func (s *EventStream) Next() (*Event, error) {
+	for {
+		event, err := s.reader.Next()
+		if err == nil {
+			s.attempts = 0
+			return event, nil
+		}
+		if s.reader.HasLastEventID() {
+			s.lastID = s.reader.LastEventID()
+		}
+		if retry := s.reader.Retry(); retry > 0 {
+			s.retry = retry
+		}
+		if ctxErr := s.ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if s.attempts >= s.reconnects {
+			return nil, err
+		}
+		if err := s.reconnect(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// reconnect waits for the retry delay and opens the stream again, until it
+// connects or runs out of attempts. A 204 No Content response ends the stream.
+func (s *EventStream) reconnect() error {
+	_ = s.resp.Close()
+	for {
+		s.attempts++
+		timer := time.NewTimer(s.retry)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return s.ctx.Err()
+		case <-timer.C:
+		}
+
+		resp, err := s.connect(s.lastID)
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) || s.attempts >= s.reconnects {
+				return err
+			}
+			continue
+		}
+		if resp.StatusCode == http.StatusNoContent {
+			_ = resp.Close()
+			return io.EOF
+		}
+		s.resp = resp
+		s.reader = NewEventReader(resp)
+		s.reader.lastID = s.lastID
+		return nil
+	}
+}
+
+// LastEventID returns the last event id the stream set
+func (s *EventStream) LastEventID() string {
+	if s.reader.HasLastEventID() {
+		return s.reader.LastEventID()
+	}
+	return s.lastID
+}
+
+// Close closes the stream's connection
+func (s *EventStream) Close() error {
+	return s.resp.Close()
+}
+
+// EventRequester is implemented by clients reading server-sent events, such
+// as *BaseClient
+type EventRequester interface {
+	// RequestEvents makes a request and reads the response as server-sent events
+	RequestEvents(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*EventStream, error)
+}
+
+var _ EventRequester = (*BaseClient)(nil)
+
+// RequestEvents makes a request like RequestStream and reads the response
+// as server-sent events. Requests accept text/event-stream unless another
+// Accept option is given. Reconnecting with WithReconnect sends the request
+// again, so its body must not be an io.Reader.
+func (c *BaseClient) RequestEvents(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*EventStream, error) {
+	opts = append([]RequestOption{WithAccept("text/event-stream")}, opts...)
+	config := &RequestConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	stream := &EventStream{
+		ctx:        ctx,
+		retry:      defaultRetry,
+		reconnects: config.Reconnects,
+		connect: func(lastEventID string) (*StreamResponse, error) {
+			if lastEventID == "" {
+				return c.RequestStream(ctx, method, path, body, opts...)
+			}
+			return c.RequestStream(ctx, method, path, body, append(opts[:len(opts):len(opts)], WithHeader("Last-Event-ID", lastEventID))...)
+		},
+	}
+
+	resp, err := stream.connect("")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		stream.reconnects = 0
+	}
+	stream.resp = resp
+	stream.reader = NewEventReader(resp)
+	return stream, nil
+}
+
+// DecodeEvent decodes the data of an event into v. Strings are set to the
+// data as is, *Event to the whole event, and other types are decoded as JSON.
+func DecodeEvent(event *Event, v interface{}) error {
+	switch target := v.(type) {
+	case *Event:
+		*target = *event
+	case *string:
+		*target = event.Data
+	default:
+		if err := json.Unmarshal([]byte(event.Data), v); err != nil {
+			return fmt.Errorf("failed to parse event: %w", err)
+		}
+	}
+	return nil
+}
+
+// Events returns an iterator over the events of the stream that open
+// returns, their data decoded into T. The stream is opened when the
+// iteration starts and closed when it stops. Errors opening or reading the
+// stream end the iteration; events that fail to decode are yielded as
+// errors, and the iteration goes on if the loop continues.
+func Events[T any](open func() (*EventStream, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		stream, err := open()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer func() {
+			_ = stream.Close()
+		}()
+
+		for {
+			event, err := stream.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var v T
+			if err := DecodeEvent(event, &v); err != nil {
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		want   []Event
+	}{
+		{
+			name:   "data",
+			stream: "data: hello\n\ndata:world\n\n",
+			want:   []Event{{Type: "message", Data: "hello"}, {Type: "message", Data: "world"}},
+		},
+		{
+			name:   "multi-line data",
+			stream: "data: first\ndata:  second\ndata\n\n",
+			want:   []Event{{Type: "message", Data: "first\n second\n"}},
+		},
+		{
+			name:   "event type and id",
+			stream: "event: update\nid: 7\ndata: {}\n\ndata: next\n\n",
+			want:   []Event{{ID: "7", Type: "update", Data: "{}"}, {ID: "7", Type: "message", Data: "next"}},
+		},
+		{
+			name:   "comments and blocks without data",
+			stream: ": keep-alive\n\nevent: ping\n\nid: 2\n\ndata: x\n\n",
+			want:   []Event{{ID: "2", Type: "message", Data: "x"}},
+		},
+		{
+			name:   "CRLF and CR line endings",
+			stream: "data: a\r\n\r\ndata: b\r\rdata: c\n\n",
+			want:   []Event{{Type: "message", Data: "a"}, {Type: "message", Data: "b"}, {Type: "message", Data: "c"}},
+		},
+		{
+			name:   "unknown fields",
+			stream: "foo: bar\ndata: x\n\n",
+			want:   []Event{{Type: "message", Data: "x"}},
+		},
+		{
+			name:   "event cut off",
+			stream: "data: done\n\ndata: partial\n",
+			want:   []Event{{Type: "message", Data: "done"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewEventReader(strings.NewReader(tt.stream))
+			var got []Event
+			for {
+				event, err := reader.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				got = append(got, *event)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventReader_Retry(t *testing.T) {
+	reader := NewEventReader(strings.NewReader("retry: 1500\nretry: soon\nid: 3\n\n"))
+	if _, err := reader.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Next() error = %v, want io.EOF", err)
+	}
+	if reader.Retry() != 1500*time.Millisecond || reader.LastEventID() != "3" {
+		t.Errorf("Retry() = %v, LastEventID() = %q", reader.Retry(), reader.LastEventID())
+	}
+}
+
+func TestBaseClient_RequestEvents(t *testing.T) {
+	var lastEventIDs []string
+	streams := []*http.Response{
+		mockResponse(200, "retry: 1\nid: 1\ndata: a\n\ndata: cut"),
+		mockResponse(200, "data: b\n\n"),
+		mockResponse(204, ""),
+	}
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Accept"); got != "text/event-stream" {
+				t.Errorf("expected Accept text/event-stream, got %s", got)
+			}
+			lastEventIDs = append(lastEventIDs, req.Header.Get("Last-Event-ID"))
+			resp := streams[0]
+			streams = streams[1:]
+			return resp, nil
+		}},
+	}
+
+	stream, err := client.RequestEvents(context.Background(), "GET", "events", nil, WithReconnect(1))
+	if err != nil {
+		t.Fatalf("RequestEvents() error = %v", err)
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	var data []string
+	for {
+		event, err := stream.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		data = append(data, event.Data)
+	}
+
+	// The stream resumes from the last event id until the server answers 204
+	if !reflect.DeepEqual(data, []string{"a", "b"}) {
+		t.Errorf("events = %v, want [a b]", data)
+	}
+	if !reflect.DeepEqual(lastEventIDs, []string{"", "1", "1"}) {
+		t.Errorf("Last-Event-ID headers = %q", lastEventIDs)
+	}
+}
+
+func TestBaseClient_RequestEventsIDReset(t *testing.T) {
+	var lastEventIDs []string
+	streams := []*http.Response{
+		mockResponse(200, "retry: 1\nid: 1\ndata: a\n\n"),
+		mockResponse(200, "id:\ndata: b\n\n"),
+		mockResponse(204, ""),
+	}
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			lastEventIDs = append(lastEventIDs, req.Header.Get("Last-Event-ID"))
+			resp := streams[0]
+			streams = streams[1:]
+			return resp, nil
+		}},
+	}
+
+	stream, err := client.RequestEvents(context.Background(), "GET", "events", nil, WithReconnect(1))
+	if err != nil {
+		t.Fatalf("RequestEvents() error = %v", err)
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+	for {
+		if _, err := stream.Next(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("Next() error = %v", err)
+			}
+			break
+		}
+	}
+
+	// An empty id resets the last event id, which is then no longer sent
+	if !reflect.DeepEqual(lastEventIDs, []string{"", "1", ""}) {
+		t.Errorf("Last-Event-ID headers = %q", lastEventIDs)
+	}
+	if id := stream.LastEventID(); id != "" {
+		t.Errorf("LastEventID() = %q, want empty", id)
+	}
+}
+
+func TestBaseClient_RequestEventsError(t *testing.T) {
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(401, `{"error": "unauthorized"}`), nil
+		}},
+	}
+
+	_, err := client.RequestEvents(context.Background(), "GET", "events", nil, WithReconnect(3))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Errorf("RequestEvents() error = %v, want *APIError", err)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	type chunk struct {
+		Text string `json:"text"`
+	}
+
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(200, "data: {\"text\":\"a\"}\n\ndata: [DONE]\n\ndata: {\"text\":\"b\"}\n\n"), nil
+		}},
+	}
+
+	var texts []string
+	var decodeErrs int
+	for c, err := range Events[chunk](func() (*EventStream, error) {
+		return client.RequestEvents(context.Background(), "GET", "events", nil)
+	}) {
+		if err != nil {
+			decodeErrs++
+			continue
+		}
+		texts = append(texts, c.Text)
+	}
+
+	// Events that fail to decode don't end the iteration
+	if !reflect.DeepEqual(texts, []string{"a", "b"}) || decodeErrs != 1 {
+		t.Errorf("texts = %v, errors = %d", texts, decodeErrs)
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	event := &Event{ID: "1", Type: "message", Data: `"quoted"`}
+
+	var s string
+	if err := DecodeEvent(event, &s); err != nil || s != `"quoted"` {
+		t.Errorf("DecodeEvent(*string) = %q, %v", s, err)
+	}
+	var e Event
+	if err := DecodeEvent(event, &e); err != nil || e != *event {
+		t.Errorf("DecodeEvent(*Event) = %+v, %v", e, err)
+	}
+	var n int
+	if err := DecodeEvent(event, &n); err == nil {
+		t.Error("DecodeEvent(*int) should fail on non-numeric data")
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// RequestForm makes an application/x-www-form-urlencoded request. The body is
+// either url.Values or a struct whose set fields are serialized like query
+// parameters named after their JSON names, using the form style with explode
+// unless a part encoding declares another style.
+func (c *BaseClient) RequestForm(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error) {
+	config := &RequestConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	bodyReader, err := c.formBody(body, config.PartEncodings)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, WithContentType("application/x-www-form-urlencoded"))
+
+	return c.Request(ctx, method, path, bodyReader, opts...)
+}
+
+// formBody validates and encodes a form request body
+func (c *BaseClient) formBody(body interface{}, encodings map[string]PartEncoding) (io.Reader, error) {
+	if err := c.validateBody(body); err != nil {
+		return nil, err
+	}
+
+	values, err := encodeForm(body, encodings)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(values.Encode()), nil
+}
+
+// encodeForm serializes a form body
+func encodeForm(body interface{}, encodings map[string]PartEncoding) (url.Values, error) {
+	if values, ok := body.(url.Values); ok {
+		return values, nil
+	}
+
+	values := url.Values{}
+	v, ok := paramValue(body)
+	if !ok {
+		return values, nil
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form body must be a struct, got %s", v.Type())
+	}
+
+	for _, field := range paramFields(v) {
+		style, explode := StyleForm, true
+		if encoding, ok := encodings[field.name]; ok && encoding.Style != "" {
+			style, explode = encoding.Style, encoding.Explode
+		}
+		for k, vs := range QueryParam(field.name, field.value.Interface(), style, explode) {
+			values[k] = append(values[k], vs...)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type tokenRequest struct {
+	GrantType string            `json:"grant_type"`
+	Scope     []string          `json:"scope,omitempty"`
+	ClientID  *string           `json:"client_id,omitempty"`
+	Filter    map[string]string `json:"filter,omitempty"`
+	Extra     map[string]string `json:"extra,omitempty"`
+}
+
+type formGeo struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type formAddress struct {
+	Street string   `json:"street"`
+	Geo    *formGeo `json:"geo,omitempty"`
+	Lines  []string `json:"lines,omitempty"`
+}
+
+type signupRequest struct {
+	Name    string       `json:"name"`
+	Address *formAddress `json:"address,omitempty"`
+}
+
+func TestEncodeForm(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      interface{}
+		encodings map[string]PartEncoding
+		want      string
+	}{
+		{
+			name: "form style with explode",
+			body: tokenRequest{GrantType: "client_credentials", Scope: []string{"read", "write"}},
+			want: "grant_type=client_credentials&scope=read&scope=write",
+		},
+		{
+			name: "exploded object properties",
+			body: tokenRequest{GrantType: "password", Extra: map[string]string{"user": "a"}},
+			want: "grant_type=password&user=a",
+		},
+		{
+			name: "declared styles",
+			body: tokenRequest{
+				GrantType: "password",
+				Scope:     []string{"read", "write"},
+				Filter:    map[string]string{"name": "x"},
+			},
+			encodings: map[string]PartEncoding{
+				"scope":  {Style: StyleSpaceDelimited},
+				"filter": {Style: StyleDeepObject, Explode: true},
+			},
+			want: "filter%5Bname%5D=x&grant_type=password&scope=read+write",
+		},
+		{
+			name: "url.Values",
+			body: url.Values{"a": {"1", "2"}},
+			want: "a=1&a=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := encodeForm(tt.body, tt.encodings)
+			if err != nil {
+				t.Fatalf("encodeForm() error = %v", err)
+			}
+			if got := values.Encode(); got != tt.want {
+				t.Errorf("encodeForm() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := encodeForm("text", nil); err == nil {
+		t.Error("encodeForm() should reject bodies that are not structs")
+	}
+}
+
+func TestBaseClient_RequestForm(t *testing.T) {
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Content-Type"); got != "application/x-www-form-urlencoded" {
+				t.Errorf("expected form Content-Type, got %s", got)
+			}
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != "grant_type=client_credentials" {
+				t.Errorf("unexpected body %q", body)
+			}
+			return mockResponse(200, `{}`), nil
+		}},
+	}
+
+	if _, err := client.RequestForm(context.Background(), "POST", "token", tokenRequest{GrantType: "client_credentials"}); err != nil {
+		t.Fatalf("RequestForm() error = %v", err)
+	}
+}
+
+func TestEncodeForm_NestedObjects(t *testing.T) {
+	body := signupRequest{
+		Name: "Ada",
+		Address: &formAddress{
+			Street: "Main St",
+			Geo:    &formGeo{Lat: 51.5, Lng: -0.12},
+			Lines:  []string{"a", "b"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		encodings map[string]PartEncoding
+		want      url.Values
+	}{
+		{
+			name: "exploded",
+			want: url.Values{
+				"name":     {"Ada"},
+				"street":   {"Main St"},
+				"geo[lat]": {"51.5"},
+				"geo[lng]": {"-0.12"},
+				"lines":    {"a", "b"},
+			},
+		},
+		{
+			name:      "not exploded",
+			encodings: map[string]PartEncoding{"address": {Style: StyleForm}},
+			want: url.Values{
+				"name":    {"Ada"},
+				"address": {"street,Main St,geo,lat,51.5,lng,-0.12,lines,a,b"},
+			},
+		},
+		{
+			name:      "deep object",
+			encodings: map[string]PartEncoding{"address": {Style: StyleDeepObject, Explode: true}},
+			want: url.Values{
+				"name":              {"Ada"},
+				"address[street]":   {"Main St"},
+				"address[geo][lat]": {"51.5"},
+				"address[geo][lng]": {"-0.12"},
+				"address[lines]":    {"a", "b"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := encodeForm(body, tt.encodings)
+			if err != nil {
+				t.Fatalf("encodeForm() error = %v", err)
+			}
+			// Decode the encoded body as a server would
+			got, err := url.ParseQuery(values.Encode())
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeForm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // Client is the base interface for all API clients
@@ -12,12 +15,18 @@ type Client interface {
 	Request(ctx context.Context, method, path string, body io.Reader, opts ...RequestOption) (*Response, error)
 	// RequestJSON makes a JSON request with the given parameters
 	RequestJSON(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error)
+	// RequestForm makes an application/x-www-form-urlencoded request from the fields of body
+	RequestForm(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error)
 	// BaseURL returns the base URL of the API
 	BaseURL() string
 	// SetBaseURL sets the base URL of the API
 	SetBaseURL(baseURL string)
 }
 
+// ErrNotSupported is returned by generated operations whose Client doesn't
+// implement the optional interface they need, such as MultipartRequester
+var ErrNotSupported = errors.New("request not supported by the client")
+
 // RequestEditor is a function that can modify an HTTP request before it's sent
 type RequestEditor func(ctx context.Context, req *http.Request) error
 
@@ -33,9 +42,46 @@ type RequestOption func(*RequestConfig)
 
 // RequestConfig holds configuration for a single request
 type RequestConfig struct {
+	// BaseURL overrides the client's base URL for this request. Relative
+	// URLs are resolved against the client's base URL.
+	BaseURL string
+	// Server is the request's own server, used unless BaseURL is set. Its
+	// URL takes the client's server variables and, when absolute, gives way
+	// to a configured base URL.
+	Server      *Server
 	Headers     map[string]string
 	QueryParams map[string]string
+	// QueryValues holds query parameters that may repeat, added after QueryParams
+	QueryValues url.Values
+	// Cookies are sent in the Cookie header, after any cookies request editors add
+	Cookies     map[string]string
 	ContentType string
+	// Accept lists the media types accepted in the response, defaulting to application/json
+	Accept string
+	// PartEncodings describe how properties of a multipart or form body are sent
+	PartEncodings map[string]PartEncoding
+	// Reconnects is the number of times in a row RequestEvents reconnects
+	// after the event stream drops
+	Reconnects int
+	// Security lists the security requirements of the request. When nil,
+	// the client's API key is sent as a bearer token.
+	Security []SecurityRequirement
+}
+
+// WithBaseURL sends the request to another server than the client's base URL,
+// as for operations that declare their own servers
+func WithBaseURL(baseURL string) RequestOption {
+	return func(c *RequestConfig) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithServer sends the request to the server an operation declares, unless
+// the client was configured with a base URL
+func WithServer(server Server) RequestOption {
+	return func(c *RequestConfig) {
+		c.Server = &server
+	}
 }
 
 // WithHeader adds a header to the request
@@ -58,6 +104,30 @@ func WithQueryParam(key, value string) RequestOption {
 	}
 }
 
+// WithQueryValues adds query values to the request, keeping repeated keys.
+// Use it with QueryParam to send array and object parameters.
+func WithQueryValues(values url.Values) RequestOption {
+	return func(c *RequestConfig) {
+		if c.QueryValues == nil {
+			c.QueryValues = make(url.Values)
+		}
+		for k, vs := range values {
+			c.QueryValues[k] = append(c.QueryValues[k], vs...)
+		}
+	}
+}
+
+// WithCookie adds a cookie to the request. The value is sent as is, so it
+// must only contain valid cookie characters; CookieParam escapes values.
+func WithCookie(name, value string) RequestOption {
+	return func(c *RequestConfig) {
+		if c.Cookies == nil {
+			c.Cookies = make(map[string]string)
+		}
+		c.Cookies[name] = value
+	}
+}
+
 // WithContentType sets the content type of the request
 func WithContentType(contentType string) RequestOption {
 	return func(c *RequestConfig) {
@@ -65,13 +135,37 @@ func WithContentType(contentType string) RequestOption {
 	}
 }
 
+// WithAccept sets the media types accepted in the response, in order of preference
+func WithAccept(mediaTypes ...string) RequestOption {
+	return func(c *RequestConfig) {
+		c.Accept = strings.Join(mediaTypes, ", ")
+	}
+}
+
+// WithReconnect makes RequestEvents reconnect after the event stream drops,
+// up to attempts times in a row, resuming from the last event id
+func WithReconnect(attempts int) RequestOption {
+	return func(c *RequestConfig) {
+		c.Reconnects = attempts
+	}
+}
+
 // APIError represents an API error response
 type APIError struct {
 	StatusCode int
 	Message    string
 	Details    interface{}
+	// Headers are the response headers
+	Headers map[string][]string
+	// Body is the raw response body, kept so callers can decode it into a declared error schema
+	Body []byte
 }
 
 func (e *APIError) Error() string {
 	return e.Message
 }
+
+// DecodeBody unmarshals the raw error response body into v
+func (e *APIError) DecodeBody(v interface{}) error {
+	return Decode(&Response{StatusCode: e.StatusCode, Headers: e.Headers, Body: e.Body}, v)
+}
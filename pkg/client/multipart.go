@@ -0,0 +1,218 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// File is a file sent as a part of a multipart request body. Its content is
+// streamed from Reader while the request is sent, never buffered in memory.
+type File struct {
+	// Reader supplies the file content
+	Reader io.Reader
+	// Name is the file name sent with the part
+	Name string
+	// ContentType is the part's content type, defaulting to the one declared
+	// in the spec and then to application/octet-stream
+	ContentType string
+}
+
+// PartEncoding describes how a property of a multipart or form body is sent,
+// as declared by the encoding object of the OpenAPI media type
+type PartEncoding struct {
+	// ContentType is the content type of the part
+	ContentType string
+	// Headers are additional headers of the part
+	Headers map[string]string
+	// Style and Explode serialize a property of a form body. The form style
+	// with explode is used when Style is empty.
+	Style   ParamStyle
+	Explode bool
+}
+
+var fileType = reflect.TypeOf(File{})
+
+// WithPartEncoding sets how the named property of a multipart or form body is encoded
+func WithPartEncoding(name string, encoding PartEncoding) RequestOption {
+	return func(c *RequestConfig) {
+		if c.PartEncodings == nil {
+			c.PartEncodings = make(map[string]PartEncoding)
+		}
+		c.PartEncodings[name] = encoding
+	}
+}
+
+// MultipartRequester is implemented by clients sending multipart/form-data
+// request bodies, such as *BaseClient
+type MultipartRequester interface {
+	// RequestMultipart makes a multipart/form-data request from the fields of body
+	RequestMultipart(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error)
+}
+
+var _ MultipartRequester = (*BaseClient)(nil)
+
+// RequestMultipart makes a multipart/form-data request. Each set field of the
+// body struct becomes a part named after its JSON name: File fields are sent
+// as files, arrays of primitives as one part per item, objects as JSON and
+// other values as text. The body is streamed as it is encoded.
+func (c *BaseClient) RequestMultipart(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error) {
+	config := &RequestConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	bodyReader, contentType, err := c.multipartBody(body, config.PartEncodings)
+	if err != nil {
+		return nil, err
+	}
+	// Unblock the writer if the request ends before the body is consumed
+	defer func() {
+		_ = bodyReader.Close()
+	}()
+
+	opts = append(opts, WithContentType(contentType))
+
+	return c.Request(ctx, method, path, bodyReader, opts...)
+}
+
+// multipartBody validates a multipart request body and starts encoding it
+// into the returned reader, which must be closed once the request ends
+func (c *BaseClient) multipartBody(body interface{}, encodings map[string]PartEncoding) (io.ReadCloser, string, error) {
+	if err := c.validateBody(body); err != nil {
+		return nil, "", err
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		err := writeMultipart(mw, body, encodings)
+		if err == nil {
+			err = mw.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, mw.FormDataContentType(), nil
+}
+
+// writeMultipart writes the fields of a struct body as multipart parts
+func writeMultipart(mw *multipart.Writer, body interface{}, encodings map[string]PartEncoding) error {
+	v, ok := paramValue(body)
+	if !ok {
+		return nil
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("multipart body must be a struct, got %s", v.Type())
+	}
+
+	for _, field := range paramFields(v) {
+		encoding := encodings[field.name]
+		switch {
+		case field.value.Type() == fileType:
+			if err := writeFilePart(mw, field.name, field.value.Interface().(File), encoding); err != nil {
+				return err
+			}
+		case isArrayParam(field.value) && field.value.Type().Elem() == fileType:
+			for i := 0; i < field.value.Len(); i++ {
+				if err := writeFilePart(mw, field.name, field.value.Index(i).Interface().(File), encoding); err != nil {
+					return err
+				}
+			}
+		case isArrayParam(field.value) && !isObjectType(field.value.Type().Elem()):
+			for _, item := range paramItems(field.value) {
+				if err := writePart(mw, field.name, strings.NewReader(item), "", encoding); err != nil {
+					return err
+				}
+			}
+		case isObjectParam(field.value) || isArrayParam(field.value):
+			data, err := json.Marshal(field.value.Interface())
+			if err != nil {
+				return fmt.Errorf("failed to marshal part %s: %w", field.name, err)
+			}
+			if err := writePart(mw, field.name, bytes.NewReader(data), "application/json", encoding); err != nil {
+				return err
+			}
+		default:
+			if err := writePart(mw, field.name, strings.NewReader(formatParam(field.value)), "", encoding); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeFilePart writes a file part, preferring the file's own content type
+func writeFilePart(mw *multipart.Writer, name string, file File, encoding PartEncoding) error {
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = singleContentType(encoding.ContentType)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	encoding.ContentType = contentType
+
+	reader := file.Reader
+	if reader == nil {
+		reader = strings.NewReader("")
+	}
+	filename := file.Name
+	if filename == "" {
+		filename = name
+	}
+	return createPart(mw, name, filename, reader, encoding)
+}
+
+// writePart writes a non-file part. The declared encoding's content type
+// takes precedence over defaultContentType.
+func writePart(mw *multipart.Writer, name string, content io.Reader, defaultContentType string, encoding PartEncoding) error {
+	if singleContentType(encoding.ContentType) == "" {
+		encoding.ContentType = defaultContentType
+	}
+	return createPart(mw, name, "", content, encoding)
+}
+
+// createPart writes a part with the given file name, headers and content
+func createPart(mw *multipart.Writer, name, filename string, content io.Reader, encoding PartEncoding) error {
+	header := make(textproto.MIMEHeader)
+	for k, v := range encoding.Headers {
+		header.Set(k, v)
+	}
+	disposition := fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(name))
+	if filename != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, escapeQuotes(filename))
+	}
+	header.Set("Content-Disposition", disposition)
+	if contentType := singleContentType(encoding.ContentType); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create part %s: %w", name, err)
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return fmt.Errorf("failed to write part %s: %w", name, err)
+	}
+	return nil
+}
+
+// singleContentType returns contentType unless it lists several types or
+// uses wildcards, which leave the actual type up to the caller
+func singleContentType(contentType string) string {
+	if strings.ContainsAny(contentType, ",*") {
+		return ""
+	}
+	return strings.TrimSpace(contentType)
+}
+
+// escapeQuotes escapes a Content-Disposition parameter value
+func escapeQuotes(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type uploadMetadata struct {
+	Title string `json:"title"`
+}
+
+type uploadRequest struct {
+	Avatar      File            `json:"avatar"`
+	Attachments []File          `json:"attachments,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Metadata    *uploadMetadata `json:"metadata,omitempty"`
+	Skipped     *string         `json:"skipped,omitempty"`
+}
+
+type multipartPart struct {
+	name, filename, contentType, header, content string
+}
+
+func readParts(t *testing.T, req *http.Request) []multipartPart {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("unexpected Content-Type %q", req.Header.Get("Content-Type"))
+	}
+
+	var parts []multipartPart
+	reader := multipart.NewReader(req.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(part)
+		parts = append(parts, multipartPart{
+			name:        part.FormName(),
+			filename:    part.FileName(),
+			contentType: part.Header.Get("Content-Type"),
+			header:      part.Header.Get("X-Part"),
+			content:     string(content),
+		})
+	}
+}
+
+func TestBaseClient_RequestMultipart(t *testing.T) {
+	description := "profile"
+	var parts []multipartPart
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			parts = readParts(t, req)
+			return mockResponse(201, `{}`), nil
+		}},
+	}
+
+	body := uploadRequest{
+		Avatar:      File{Reader: strings.NewReader("png data"), Name: "me.png"},
+		Attachments: []File{{Reader: strings.NewReader("a"), Name: "a.txt", ContentType: "text/plain"}},
+		Description: &description,
+		Tags:        []string{"x", "y"},
+		Metadata:    &uploadMetadata{Title: "t"},
+	}
+	_, err := client.RequestMultipart(context.Background(), "POST", "uploads", body,
+		WithPartEncoding("avatar", PartEncoding{ContentType: "image/png", Headers: map[string]string{"X-Part": "1"}}))
+	if err != nil {
+		t.Fatalf("RequestMultipart() error = %v", err)
+	}
+
+	want := []multipartPart{
+		{name: "avatar", filename: "me.png", contentType: "image/png", header: "1", content: "png data"},
+		{name: "attachments", filename: "a.txt", contentType: "text/plain", content: "a"},
+		{name: "description", content: "profile"},
+		{name: "tags", content: "x"},
+		{name: "tags", content: "y"},
+		{name: "metadata", contentType: "application/json", content: `{"title":"t"}`},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("got %d parts, want %d: %+v", len(parts), len(want), parts)
+	}
+	for i := range want {
+		if parts[i] != want[i] {
+			t.Errorf("part %d = %+v, want %+v", i, parts[i], want[i])
+		}
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk error")
+}
+
+func TestBaseClient_RequestMultipartReaderError(t *testing.T) {
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if _, err := io.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+			return mockResponse(201, `{}`), nil
+		}},
+	}
+
+	_, err := client.RequestMultipart(context.Background(), "POST", "uploads", uploadRequest{
+		Avatar: File{Reader: failingReader{}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "disk error") {
+		t.Errorf("RequestMultipart() error = %v, want the reader's error", err)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// NDJSONBody returns a request body streaming records as newline-delimited
+// JSON, one record per line. Records are encoded as the body is read, so
+// they are never held in memory together. Closing the body stops the
+// iteration, as the HTTP client does once the request is sent.
+func NDJSONBody[T any](records iter.Seq[T]) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		encoder := json.NewEncoder(pw)
+		for record := range records {
+			if err := encoder.Encode(record); err != nil {
+				pw.CloseWithError(fmt.Errorf("failed to marshal record: %w", err))
+				return
+			}
+		}
+		_ = pw.Close()
+	}()
+	return pr
+}
+
+// ChannelSeq returns an iterator over the values received from ch until it
+// is closed, to stream records sent on a channel with NDJSONBody
+func ChannelSeq[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Records returns an iterator over the newline-delimited JSON records of
+// the stream that open returns, decoded into T one line at a time. The
+// stream is opened when the iteration starts and closed when it stops; blank
+// lines are skipped. Errors opening or reading the stream end the
+// iteration; records that fail to decode are yielded as errors, and the
+// iteration goes on if the loop continues.
+func Records[T any](open func() (*StreamResponse, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		stream, err := open()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer func() {
+			_ = stream.Close()
+		}()
+
+		reader := bufio.NewReader(stream)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				var record T
+				if decodeErr := json.Unmarshal(line, &record); decodeErr != nil {
+					if !yield(zero, fmt.Errorf("failed to parse record: %w", decodeErr)) {
+						return
+					}
+				} else if !yield(record, nil) {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to read response body: %w", err))
+				return
+			}
+		}
+	}
+}
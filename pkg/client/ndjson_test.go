@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	ID int `json:"id"`
+}
+
+func TestNDJSONBody(t *testing.T) {
+	body := NDJSONBody(slices.Values([]record{{ID: 1}, {ID: 2}}))
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != "{\"id\":1}\n{\"id\":2}\n" {
+		t.Errorf("body = %q", data)
+	}
+}
+
+func TestNDJSONBody_Close(t *testing.T) {
+	stopped := make(chan struct{})
+	records := func(yield func(record) bool) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if !yield(record{ID: i}) {
+				return
+			}
+		}
+	}
+
+	// Closing the body stops an endless iteration
+	body := NDJSONBody(records)
+	if _, err := io.ReadFull(body, make([]byte, 8)); err != nil {
+		t.Fatalf("ReadFull() error = %v", err)
+	}
+	_ = body.Close()
+	<-stopped
+}
+
+func TestChannelSeq(t *testing.T) {
+	ch := make(chan record, 2)
+	ch <- record{ID: 1}
+	ch <- record{ID: 2}
+	close(ch)
+
+	if got := slices.Collect(ChannelSeq(ch)); !reflect.DeepEqual(got, []record{{ID: 1}, {ID: 2}}) {
+		t.Errorf("ChannelSeq() = %v", got)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantIDs    []int
+		wantErrors int
+	}{
+		{name: "lines", body: "{\"id\":1}\n{\"id\":2}\n", wantIDs: []int{1, 2}},
+		{name: "no final newline", body: "{\"id\":1}\n{\"id\":2}", wantIDs: []int{1, 2}},
+		{name: "blank lines and CRLF", body: "{\"id\":1}\r\n\n\r\n{\"id\":2}\r\n", wantIDs: []int{1, 2}},
+		{name: "invalid record", body: "{\"id\":1}\nnot json\n{\"id\":3}\n", wantIDs: []int{1, 3}, wantErrors: 1},
+		{name: "empty", body: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open := func() (*StreamResponse, error) {
+				return &StreamResponse{StatusCode: 200, Body: io.NopCloser(strings.NewReader(tt.body))}, nil
+			}
+			var ids []int
+			var errs int
+			for r, err := range Records[record](open) {
+				if err != nil {
+					errs++
+					continue
+				}
+				ids = append(ids, r.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || errs != tt.wantErrors {
+				t.Errorf("Records() = %v with %d errors, want %v with %d", ids, errs, tt.wantIDs, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestRecords_OpenError(t *testing.T) {
+	openErr := errors.New("unavailable")
+	var errs []error
+	for _, err := range Records[record](func() (*StreamResponse, error) { return nil, openErr }) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], openErr) {
+		t.Errorf("Records() errors = %v", errs)
+	}
+}
+
+func TestBaseClient_RequestStreamNDJSON(t *testing.T) {
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Content-Type"); got != "application/x-ndjson" {
+				t.Errorf("expected Content-Type application/x-ndjson, got %s", got)
+			}
+			// Echo the records back
+			return &http.Response{StatusCode: 200, Header: make(http.Header), Body: req.Body}, nil
+		}},
+	}
+
+	body := NDJSONBody(slices.Values([]record{{ID: 1}, {ID: 2}}))
+	var ids []int
+	for r, err := range Records[record](func() (*StreamResponse, error) {
+		return client.RequestStream(context.Background(), "POST", "echo", body, WithContentType("application/x-ndjson"))
+	}) {
+		if err != nil {
+			t.Fatalf("Records() error = %v", err)
+		}
+		ids = append(ids, r.ID)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("records = %v", ids)
+	}
+}
+
+func TestBaseClient_SendClosesUnsentBody(t *testing.T) {
+	client := &BaseClient{
+		baseURL:    "https://api.example.com/",
+		httpClient: &mockHTTPClient{},
+		requestEditors: []RequestEditor{func(ctx context.Context, req *http.Request) error {
+			return errors.New("no credentials")
+		}},
+	}
+
+	body := &closeTracker{Reader: strings.NewReader("{}\n")}
+	if _, err := client.RequestStream(context.Background(), "POST", "import", body); err == nil {
+		t.Fatal("RequestStream() should fail")
+	}
+	if !body.closed {
+		t.Error("the body of a request that isn't sent should be closed")
+	}
+}
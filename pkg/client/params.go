@@ -0,0 +1,324 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamStyle is an OpenAPI parameter serialization style
+type ParamStyle string
+
+// Parameter serialization styles defined by OpenAPI
+const (
+	StyleForm           ParamStyle = "form"
+	StyleSpaceDelimited ParamStyle = "spaceDelimited"
+	StylePipeDelimited  ParamStyle = "pipeDelimited"
+	StyleDeepObject     ParamStyle = "deepObject"
+	StyleSimple         ParamStyle = "simple"
+	StyleLabel          ParamStyle = "label"
+	StyleMatrix         ParamStyle = "matrix"
+)
+
+// QueryParam serializes a query parameter in the given style. Arrays and
+// objects (structs and maps) are expanded as described by the OpenAPI
+// specification; nil values produce no query values.
+func QueryParam(name string, value interface{}, style ParamStyle, explode bool) url.Values {
+	values := url.Values{}
+	v, ok := paramValue(value)
+	if !ok {
+		return values
+	}
+
+	switch {
+	case isArrayParam(v):
+		items := paramItems(v)
+		switch {
+		case style == StyleSpaceDelimited && !explode:
+			values.Add(name, strings.Join(items, " "))
+		case style == StylePipeDelimited && !explode:
+			values.Add(name, strings.Join(items, "|"))
+		case explode:
+			for _, item := range items {
+				values.Add(name, item)
+			}
+		default:
+			values.Add(name, strings.Join(items, ","))
+		}
+	case isObjectParam(v):
+		switch {
+		case style == StyleDeepObject:
+			addDeepObject(values, name, v)
+		case explode:
+			for _, field := range paramFields(v) {
+				addExplodedField(values, field)
+			}
+		case style == StyleSpaceDelimited:
+			values.Add(name, strings.Join(flattenFields(paramFields(v)), " "))
+		case style == StylePipeDelimited:
+			values.Add(name, strings.Join(flattenFields(paramFields(v)), "|"))
+		default:
+			values.Add(name, strings.Join(flattenFields(paramFields(v)), ","))
+		}
+	default:
+		values.Add(name, formatParam(v))
+	}
+	return values
+}
+
+// HeaderParam serializes a header parameter using the simple style
+func HeaderParam(value interface{}, explode bool) string {
+	return serializeParam("", value, StyleSimple, explode, func(s string) string { return s })
+}
+
+// CookieParam serializes a cookie parameter using the form style. Values are
+// percent-escaped, so that they never contain spaces, commas or semicolons.
+func CookieParam(value interface{}, explode bool) string {
+	return serializeParam("", value, StyleSimple, explode, url.PathEscape)
+}
+
+// PathParam serializes a path parameter in the simple, label or matrix style.
+// The result replaces the whole {name} template expression, including the
+// leading "." or ";name=" the label and matrix styles call for. Values are
+// percent-escaped, so a "/" or "?" in a value cannot change the request path.
+func PathParam(name string, value interface{}, style ParamStyle, explode bool) string {
+	return serializeParam(name, value, style, explode, url.PathEscape)
+}
+
+// serializeParam serializes a parameter in the simple, label or matrix style,
+// escaping names and values with escape while leaving the delimiters intact
+func serializeParam(name string, value interface{}, style ParamStyle, explode bool, escape func(string) string) string {
+	v, ok := paramValue(value)
+	if !ok {
+		return ""
+	}
+
+	prefix, separator := "", ","
+	switch style {
+	case StyleLabel:
+		prefix = "."
+		if explode {
+			separator = "."
+		}
+	case StyleMatrix:
+		prefix = ";"
+		if explode {
+			separator = ";"
+		}
+	}
+
+	switch {
+	case isArrayParam(v):
+		items := paramItems(v)
+		for i, item := range items {
+			items[i] = escape(item)
+		}
+		if style == StyleMatrix {
+			if explode {
+				for i, item := range items {
+					items[i] = name + "=" + item
+				}
+			} else {
+				return prefix + name + "=" + strings.Join(items, ",")
+			}
+		}
+		return prefix + strings.Join(items, separator)
+	case isObjectParam(v):
+		fields := paramFields(v)
+		if !explode {
+			flat := flattenFields(fields)
+			for i, item := range flat {
+				flat[i] = escape(item)
+			}
+			joined := strings.Join(flat, ",")
+			if style == StyleMatrix {
+				return prefix + name + "=" + joined
+			}
+			return prefix + joined
+		}
+		pairs := make([]string, len(fields))
+		for i, field := range fields {
+			pairs[i] = escape(field.name) + "=" + escape(formatParam(field.value))
+		}
+		return prefix + strings.Join(pairs, separator)
+	default:
+		if style == StyleMatrix {
+			return prefix + name + "=" + escape(formatParam(v))
+		}
+		return prefix + escape(formatParam(v))
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// paramField is a named property of an object parameter
+type paramField struct {
+	name  string
+	value reflect.Value
+}
+
+// paramValue dereferences value, reporting false for nil values
+func paramValue(value interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
+
+// isArrayParam reports whether v is serialized as an array
+func isArrayParam(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// isObjectParam reports whether v is serialized as an object
+func isObjectParam(v reflect.Value) bool {
+	return isObjectType(v.Type())
+}
+
+// isObjectType reports whether values of type t, or of the type t points
+// to, are serialized as objects
+func isObjectType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map || (t.Kind() == reflect.Struct && t != timeType)
+}
+
+// paramItems formats the elements of an array parameter
+func paramItems(v reflect.Value) []string {
+	items := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if item, ok := paramValue(v.Index(i).Interface()); ok {
+			items = append(items, formatParam(item))
+		}
+	}
+	return items
+}
+
+// paramFields lists the set properties of an object parameter. Struct fields
+// are named after their JSON tags and keep their declaration order, map
+// entries are sorted by key.
+func paramFields(v reflect.Value) []paramField {
+	var fields []paramField
+	if v.Kind() == reflect.Map {
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			if value, ok := paramValue(v.MapIndex(key).Interface()); ok {
+				fields = append(fields, paramField{name: fmt.Sprint(key.Interface()), value: value})
+			}
+		}
+		return fields
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Name
+		if tag := sf.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if jsonName, _, _ := strings.Cut(tag, ","); jsonName != "" {
+				name = jsonName
+			}
+		}
+		if value, ok := paramValue(v.Field(i).Interface()); ok {
+			fields = append(fields, paramField{name: name, value: value})
+		}
+	}
+	return fields
+}
+
+// flattenFields lists the names and values of fields alternately, as
+// non-exploded object parameters are serialized
+func flattenFields(fields []paramField) []string {
+	flat := make([]string, 0, 2*len(fields))
+	for _, field := range fields {
+		flat = append(flat, field.name, formatParam(field.value))
+	}
+	return flat
+}
+
+// addExplodedField adds a property of an exploded object parameter under its
+// own name. Nested arrays repeat the name for each item, and nested objects
+// are added as name[property]=value, as the deepObject style does.
+func addExplodedField(values url.Values, field paramField) {
+	switch {
+	case isObjectParam(field.value):
+		addDeepObject(values, field.name, field.value)
+	case isArrayParam(field.value):
+		for _, item := range paramItems(field.value) {
+			values.Add(field.name, item)
+		}
+	default:
+		values.Add(field.name, formatParam(field.value))
+	}
+}
+
+// addDeepObject adds the properties of an object as name[property]=value,
+// nesting brackets for nested objects
+func addDeepObject(values url.Values, name string, v reflect.Value) {
+	for _, field := range paramFields(v) {
+		key := name + "[" + field.name + "]"
+		switch {
+		case isObjectParam(field.value):
+			addDeepObject(values, key, field.value)
+		case isArrayParam(field.value):
+			for _, item := range paramItems(field.value) {
+				values.Add(key, item)
+			}
+		default:
+			values.Add(key, formatParam(field.value))
+		}
+	}
+}
+
+// formatParam formats a primitive parameter value. Times use RFC 3339.
+// Arrays and objects nested in a value are joined with commas, as their
+// non-exploded form style calls for.
+func formatParam(v reflect.Value) string {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	}
+	switch {
+	case isArrayParam(v):
+		return strings.Join(paramItems(v), ",")
+	case isObjectParam(v):
+		return strings.Join(flattenFields(paramFields(v)), ",")
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+type paramFilter struct {
+	Name  string  `json:"name"`
+	Role  *string `json:"role,omitempty"`
+	Owner *struct {
+		ID int64 `json:"id"`
+	} `json:"owner,omitempty"`
+}
+
+func TestQueryParam(t *testing.T) {
+	role := "admin"
+	created := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		style   ParamStyle
+		explode bool
+		want    string
+	}{
+		{"primitive", int64(5), StyleForm, true, "p=5"},
+		{"float", 1500000.5, StyleForm, true, "p=1500000.5"},
+		{"time", created, StyleForm, true, "p=2024-05-01T12%3A30%3A00Z"},
+		{"pointer", &role, StyleForm, true, "p=admin"},
+		{"nil pointer", (*string)(nil), StyleForm, true, ""},
+		{"nil slice", []string(nil), StyleForm, true, ""},
+		{"form exploded array", []string{"a", "b"}, StyleForm, true, "p=a&p=b"},
+		{"form array", []string{"a", "b"}, StyleForm, false, "p=a%2Cb"},
+		{"space delimited array", []int{1, 2}, StyleSpaceDelimited, false, "p=1+2"},
+		{"pipe delimited array", []int{1, 2}, StylePipeDelimited, false, "p=1%7C2"},
+		{"form exploded object", paramFilter{Name: "x", Role: &role}, StyleForm, true, "name=x&role=admin"},
+		{"form object", paramFilter{Name: "x"}, StyleForm, false, "p=name%2Cx"},
+		{"deep object", paramFilter{Name: "x", Role: &role}, StyleDeepObject, true, "p%5Bname%5D=x&p%5Brole%5D=admin"},
+		{"deep object map", map[string]int{"b": 2, "a": 1}, StyleDeepObject, true, "p%5Ba%5D=1&p%5Bb%5D=2"},
+		{"nested deep object", paramFilter{Name: "x", Owner: &struct {
+			ID int64 `json:"id"`
+		}{ID: 7}}, StyleDeepObject, true, "p%5Bname%5D=x&p%5Bowner%5D%5Bid%5D=7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := QueryParam("p", tt.value, tt.style, tt.explode).Encode()
+			if got != tt.want {
+				t.Errorf("QueryParam() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathParam(t *testing.T) {
+	object := map[string]string{"role": "admin", "first": "Alex"}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		style   ParamStyle
+		explode bool
+		want    string
+	}{
+		{"simple primitive", 5, StyleSimple, false, "5"},
+		{"simple array", []int{3, 4, 5}, StyleSimple, false, "3,4,5"},
+		{"simple object", object, StyleSimple, false, "first,Alex,role,admin"},
+		{"simple exploded object", object, StyleSimple, true, "first=Alex,role=admin"},
+		{"label primitive", 5, StyleLabel, false, ".5"},
+		{"label array", []int{3, 4, 5}, StyleLabel, false, ".3,4,5"},
+		{"label exploded array", []int{3, 4, 5}, StyleLabel, true, ".3.4.5"},
+		{"label exploded object", object, StyleLabel, true, ".first=Alex.role=admin"},
+		{"matrix primitive", 5, StyleMatrix, false, ";id=5"},
+		{"matrix array", []int{3, 4, 5}, StyleMatrix, false, ";id=3,4,5"},
+		{"matrix exploded array", []int{3, 4, 5}, StyleMatrix, true, ";id=3;id=4;id=5"},
+		{"matrix object", object, StyleMatrix, false, ";id=first,Alex,role,admin"},
+		{"matrix exploded object", object, StyleMatrix, true, ";first=Alex;role=admin"},
+		{"escaped primitive", "a/b?c d", StyleSimple, false, "a%2Fb%3Fc%20d"},
+		{"escaped array", []string{"a,b", "c;d"}, StyleMatrix, false, ";id=a%2Cb,c%3Bd"},
+		{"escaped object", map[string]string{"k/1": "v/1"}, StyleLabel, true, ".k%2F1=v%2F1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PathParam("id", tt.value, tt.style, tt.explode); got != tt.want {
+				t.Errorf("PathParam() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderParam(t *testing.T) {
+	if got := HeaderParam([]string{"a", "b"}, false); got != "a,b" {
+		t.Errorf("HeaderParam() = %q, want %q", got, "a,b")
+	}
+	if got := HeaderParam("a/b c", false); got != "a/b c" {
+		t.Errorf("HeaderParam() = %q, want the value unescaped", got)
+	}
+	if got := HeaderParam(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), false); got != "2024-05-01T00:00:00Z" {
+		t.Errorf("HeaderParam() = %q, want RFC 3339 time", got)
+	}
+}
+
+func TestWithQueryValues(t *testing.T) {
+	config := &RequestConfig{}
+	WithQueryValues(url.Values{"status": {"a"}})(config)
+	WithQueryValues(url.Values{"status": {"b"}})(config)
+
+	if got := config.QueryValues["status"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("QueryValues[status] = %v, want [a b]", got)
+	}
+}
+
+func TestCookieParam(t *testing.T) {
+	if got := CookieParam([]string{"a b", "c;d"}, false); got != "a%20b,c%3Bd" {
+		t.Errorf("CookieParam() = %q, want %q", got, "a%20b,c%3Bd")
+	}
+}
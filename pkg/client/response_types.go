@@ -11,12 +11,13 @@ type MultiResponse struct {
 	parsedBody interface{}
 }
 
-// As attempts to unmarshal the response into the provided type
+// As attempts to unmarshal the response into the provided type, decoding it
+// according to its Content-Type
 func (r *MultiResponse) As(v interface{}) error {
 	if r.parsedBody != nil {
 		return r.tryTypeAssertion(v)
 	}
-	return ParseJSON(&r.Response, v)
+	return Decode(&r.Response, v)
 }
 
 // Is checks if the response status code matches
@@ -44,7 +45,7 @@ func (r *MultiResponse) tryTypeAssertion(v interface{}) error {
 		}
 	default:
 		// For other types, re-parse from body
-		return ParseJSON(&r.Response, v)
+		return Decode(&r.Response, v)
 	}
 	return fmt.Errorf("type assertion failed")
 }
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Security scheme types
+const (
+	SecurityAPIKey        = "apiKey"
+	SecurityHTTP          = "http"
+	SecurityOAuth2        = "oauth2"
+	SecurityOpenIDConnect = "openIdConnect"
+	SecurityMutualTLS     = "mutualTLS"
+)
+
+// SecurityScheme describes how the credential of a security scheme is sent
+type SecurityScheme struct {
+	// Type is one of SecurityAPIKey, SecurityHTTP, SecurityOAuth2,
+	// SecurityOpenIDConnect and SecurityMutualTLS
+	Type string
+	// Scheme is the HTTP authorization scheme, such as basic or bearer
+	Scheme string
+	// In is where an API key is sent: header, query or cookie
+	In string
+	// Name is the name of the header, query parameter or cookie of an API key
+	Name string
+}
+
+// SecurityRequirement names the security schemes that together authorize a request
+type SecurityRequirement []string
+
+// Credential is the secret sent for a security scheme
+type Credential struct {
+	// Value is an API key, or the token of HTTP schemes other than basic
+	Value string
+	// Username and Password are sent by HTTP basic schemes
+	Username string
+	Password string
+	// TokenSource provides the access tokens of OAuth2 and OpenID Connect
+	// schemes, which are sent as bearer tokens
+	TokenSource OAuth2TokenSource
+}
+
+// WithSecurity sets the security requirements of a request, any of which
+// authorizes it. Credentials are only sent for the first requirement whose
+// schemes all have one; requests without requirements send none.
+func WithSecurity(requirements ...SecurityRequirement) RequestOption {
+	return func(c *RequestConfig) {
+		c.Security = append([]SecurityRequirement{}, requirements...)
+	}
+}
+
+// CredentialSetter is implemented by clients sending the credentials of
+// security schemes, such as *BaseClient
+type CredentialSetter interface {
+	// SetCredential sets the credential sent for a security scheme
+	SetCredential(scheme string, credential Credential)
+}
+
+var _ CredentialSetter = (*BaseClient)(nil)
+
+// SetCredential sets the credential sent for a security scheme
+func (c *BaseClient) SetCredential(scheme string, credential Credential) {
+	c.credentialsMu.Lock()
+	defer c.credentialsMu.Unlock()
+	if c.credentials == nil {
+		c.credentials = make(map[string]Credential)
+	}
+	c.credentials[scheme] = credential
+}
+
+// authorize adds the credentials of the first security requirement the
+// client can satisfy to a request. Requirements without schemes, which make
+// authorization optional, are only used when no other can be satisfied.
+func (c *BaseClient) authorize(ctx context.Context, req *http.Request, requirements []SecurityRequirement) error {
+	// Token sources may block or set credentials, so they run outside the lock
+	requirement, credentials := c.selectCredentials(requirements)
+	for i, name := range requirement {
+		if err := c.applyCredential(ctx, req, c.securitySchemes[name], credentials[i]); err != nil {
+			return fmt.Errorf("failed to apply credential for %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// selectCredentials returns the first of the requirements the client has
+// credentials for, along with a copy of those credentials
+func (c *BaseClient) selectCredentials(requirements []SecurityRequirement) (SecurityRequirement, []Credential) {
+	c.credentialsMu.RLock()
+	defer c.credentialsMu.RUnlock()
+
+	for _, requirement := range requirements {
+		if len(requirement) == 0 || !c.satisfies(requirement) {
+			continue
+		}
+		credentials := make([]Credential, len(requirement))
+		for i, name := range requirement {
+			credentials[i] = c.credential(name)
+		}
+		return requirement, credentials
+	}
+	return nil, nil
+}
+
+// satisfies reports whether the client has credentials for all schemes of a requirement
+func (c *BaseClient) satisfies(requirement SecurityRequirement) bool {
+	for _, name := range requirement {
+		scheme, ok := c.securitySchemes[name]
+		if !ok {
+			return false
+		}
+		if scheme.Type == SecurityMutualTLS {
+			// Client certificates are configured on the HTTP client
+			continue
+		}
+		credential := c.credential(name)
+		if credential.Value == "" && credential.Username == "" && credential.TokenSource == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// credential returns the credential set for a scheme. Header and query API
+// keys and bearer tokens fall back to the APIKey of the client's config.
+func (c *BaseClient) credential(name string) Credential {
+	if credential, ok := c.credentials[name]; ok {
+		return credential
+	}
+	scheme := c.securitySchemes[name]
+	if (scheme.Type == SecurityAPIKey && scheme.In != "cookie") || (scheme.Type == SecurityHTTP && strings.EqualFold(scheme.Scheme, "bearer")) {
+		return Credential{Value: c.apiKey}
+	}
+	return Credential{}
+}
+
+// applyCredential adds the credential of a security scheme to a request
+func (c *BaseClient) applyCredential(ctx context.Context, req *http.Request, scheme SecurityScheme, credential Credential) error {
+	switch scheme.Type {
+	case SecurityAPIKey:
+		switch scheme.In {
+		case "query":
+			query := req.URL.Query()
+			query.Set(scheme.Name, credential.Value)
+			req.URL.RawQuery = query.Encode()
+		case "cookie":
+			req.AddCookie(&http.Cookie{Name: scheme.Name, Value: credential.Value})
+		default:
+			req.Header.Set(scheme.Name, credential.Value)
+		}
+	case SecurityHTTP:
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			req.SetBasicAuth(credential.Username, credential.Password)
+		case "bearer":
+			req.Header.Set("Authorization", "Bearer "+credential.Value)
+		default:
+			req.Header.Set("Authorization", scheme.Scheme+" "+credential.Value)
+		}
+	case SecurityOAuth2, SecurityOpenIDConnect:
+		token := credential.Value
+		if credential.TokenSource != nil {
+			var err error
+			if token, err = credential.TokenSource.Token(ctx); err != nil {
+				return fmt.Errorf("failed to get token: %w", err)
+			}
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
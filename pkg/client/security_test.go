@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(ctx context.Context) (string, error) {
+	if s == "" {
+		return "", errors.New("no token")
+	}
+	return string(s), nil
+}
+
+var testSecuritySchemes = map[string]SecurityScheme{
+	"apiKeyHeader": {Type: SecurityAPIKey, In: "header", Name: "X-API-Key"},
+	"apiKeyQuery":  {Type: SecurityAPIKey, In: "query", Name: "api_key"},
+	"apiKeyCookie": {Type: SecurityAPIKey, In: "cookie", Name: "session"},
+	"basic":        {Type: SecurityHTTP, Scheme: "basic"},
+	"bearer":       {Type: SecurityHTTP, Scheme: "bearer"},
+	"oauth":        {Type: SecurityOAuth2},
+	"mtls":         {Type: SecurityMutualTLS},
+}
+
+func TestBaseClient_Security(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiKey      string
+		credentials map[string]Credential
+		opts        []RequestOption
+		check       func(t *testing.T, req *http.Request)
+	}{
+		{
+			name:        "API key header",
+			credentials: map[string]Credential{"apiKeyHeader": {Value: "k1"}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{"apiKeyHeader"})},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("X-API-Key") != "k1" || req.Header.Get("Authorization") != "" {
+					t.Errorf("headers = %v", req.Header)
+				}
+			},
+		},
+		{
+			name:        "API key query and cookie together",
+			credentials: map[string]Credential{"apiKeyQuery": {Value: "q"}, "apiKeyCookie": {Value: "c"}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{"apiKeyQuery", "apiKeyCookie"})},
+			check: func(t *testing.T, req *http.Request) {
+				cookie, err := req.Cookie("session")
+				if req.URL.Query().Get("api_key") != "q" || err != nil || cookie.Value != "c" {
+					t.Errorf("URL = %s, cookies = %v", req.URL, req.Cookies())
+				}
+			},
+		},
+		{
+			name:        "basic",
+			credentials: map[string]Credential{"basic": {Username: "user", Password: "pass"}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{"basic"})},
+			check: func(t *testing.T, req *http.Request) {
+				if username, password, ok := req.BasicAuth(); !ok || username != "user" || password != "pass" {
+					t.Errorf("Authorization = %q", req.Header.Get("Authorization"))
+				}
+			},
+		},
+		{
+			name:        "first satisfied requirement",
+			credentials: map[string]Credential{"oauth": {TokenSource: staticTokenSource("tok")}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{"basic"}, SecurityRequirement{"oauth"})},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("Authorization") != "Bearer tok" {
+					t.Errorf("Authorization = %q", req.Header.Get("Authorization"))
+				}
+			},
+		},
+		{
+			name:        "optional security sends credentials that are set",
+			credentials: map[string]Credential{"bearer": {Value: "tok"}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{}, SecurityRequirement{"bearer"})},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("Authorization") != "Bearer tok" {
+					t.Errorf("Authorization = %q", req.Header.Get("Authorization"))
+				}
+			},
+		},
+		{
+			name:   "API key falls back to the config's",
+			apiKey: "config-key",
+			opts:   []RequestOption{WithSecurity(SecurityRequirement{"apiKeyHeader"})},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("X-API-Key") != "config-key" || req.Header.Get("Authorization") != "" {
+					t.Errorf("headers = %v", req.Header)
+				}
+			},
+		},
+		{
+			name:   "cookies don't fall back to the config's API key",
+			apiKey: "config-key",
+			opts:   []RequestOption{WithSecurity(SecurityRequirement{"apiKeyCookie"}, SecurityRequirement{})},
+			check: func(t *testing.T, req *http.Request) {
+				if len(req.Cookies()) != 0 || req.Header.Get("Authorization") != "" {
+					t.Errorf("headers = %v", req.Header)
+				}
+			},
+		},
+		{
+			name:        "mutual TLS needs no credential",
+			credentials: map[string]Credential{"apiKeyHeader": {Value: "k1"}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{"mtls", "apiKeyHeader"})},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("X-API-Key") != "k1" {
+					t.Errorf("headers = %v", req.Header)
+				}
+			},
+		},
+		{
+			name:        "anonymous",
+			apiKey:      "config-key",
+			credentials: map[string]Credential{"apiKeyHeader": {Value: "k1"}},
+			opts:        []RequestOption{WithSecurity()},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("X-API-Key") != "" || req.Header.Get("Authorization") != "" {
+					t.Errorf("headers = %v", req.Header)
+				}
+			},
+		},
+		{
+			name:        "unsatisfied",
+			credentials: map[string]Credential{"apiKeyHeader": {Value: "k1"}},
+			opts:        []RequestOption{WithSecurity(SecurityRequirement{"basic"})},
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("X-API-Key") != "" || req.Header.Get("Authorization") != "" {
+					t.Errorf("headers = %v", req.Header)
+				}
+			},
+		},
+		{
+			name:   "without security requirements",
+			apiKey: "config-key",
+			check: func(t *testing.T, req *http.Request) {
+				if req.Header.Get("Authorization") != "Bearer config-key" {
+					t.Errorf("Authorization = %q", req.Header.Get("Authorization"))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &BaseClient{
+				baseURL:         "https://api.example.com/",
+				apiKey:          tt.apiKey,
+				securitySchemes: testSecuritySchemes,
+				httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+					tt.check(t, req)
+					return mockResponse(200, "{}"), nil
+				}},
+			}
+			for name, credential := range tt.credentials {
+				client.SetCredential(name, credential)
+			}
+			if _, err := client.Request(context.Background(), "GET", "pets", nil, tt.opts...); err != nil {
+				t.Fatalf("Request() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestBaseClient_SecurityTokenError(t *testing.T) {
+	client := &BaseClient{
+		baseURL:         "https://api.example.com/",
+		securitySchemes: testSecuritySchemes,
+		httpClient:      &mockHTTPClient{},
+	}
+	client.SetCredential("oauth", Credential{TokenSource: staticTokenSource("")})
+
+	if _, err := client.Request(context.Background(), "GET", "pets", nil, WithSecurity(SecurityRequirement{"oauth"})); err == nil {
+		t.Error("Request() should fail when the token can't be fetched")
+	}
+}
+
+// refreshingTokenSource stores each token it hands out as the client's
+// bearer credential
+type refreshingTokenSource struct {
+	client *BaseClient
+}
+
+func (s refreshingTokenSource) Token(ctx context.Context) (string, error) {
+	s.client.SetCredential("bearer", Credential{Value: "refreshed"})
+	return "tok", nil
+}
+
+func TestBaseClient_SecurityTokenSourceSetsCredential(t *testing.T) {
+	client := &BaseClient{
+		baseURL:         "https://api.example.com/",
+		securitySchemes: testSecuritySchemes,
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Authorization"); got != "Bearer tok" {
+				t.Errorf("Authorization = %q, want Bearer tok", got)
+			}
+			return mockResponse(http.StatusOK, "{}"), nil
+		}},
+	}
+	client.SetCredential("oauth", Credential{TokenSource: refreshingTokenSource{client: client}})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Request(context.Background(), "GET", "pets", nil, WithSecurity(SecurityRequirement{"oauth"}))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Request() deadlocked while the token source set a credential")
+	}
+}
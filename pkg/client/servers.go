@@ -0,0 +1,103 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Server is a server of an API, whose URL may hold {variable} templates
+type Server struct {
+	// Name identifies the server when selecting it with Config.Server
+	Name        string
+	URL         string
+	Description string
+	// Variables are the variables of the URL template by name
+	Variables map[string]ServerVariable
+}
+
+// ServerVariable is a variable of a server URL template
+type ServerVariable struct {
+	// Default is the value used when none is given
+	Default string
+	// Enum restricts the variable to these values, when set
+	Enum        []string
+	Description string
+}
+
+// ServerURL returns the server's URL with its variables set to vars, or to
+// their defaults for those vars leave out. Vars the server doesn't declare,
+// which other servers may use, are ignored. It fails for values outside a
+// variable's enum.
+func (s Server) ServerURL(vars map[string]string) (string, error) {
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	serverURL := s.URL
+	for _, name := range names {
+		variable := s.Variables[name]
+		value, ok := vars[name]
+		if !ok {
+			value = variable.Default
+		}
+		if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, value) {
+			return "", fmt.Errorf("invalid value %q for server variable %q, must be one of: %s", value, name, strings.Join(variable.Enum, ", "))
+		}
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", value)
+	}
+	return serverURL, nil
+}
+
+// selectServer returns the server of servers named name, ignoring case, or
+// at the index name holds, such as "0". It returns the first when name is "".
+func selectServer(servers []Server, name string) (Server, error) {
+	if name == "" {
+		return servers[0], nil
+	}
+	for _, server := range servers {
+		if strings.EqualFold(server.Name, name) {
+			return server, nil
+		}
+	}
+	if index, err := strconv.Atoi(name); err == nil && index >= 0 && index < len(servers) {
+		return servers[index], nil
+	}
+	return Server{}, fmt.Errorf("unknown server %q", name)
+}
+
+// serverBaseURL returns the URL of the server the config selects
+func serverBaseURL(config *Config) (string, error) {
+	server, err := selectServer(config.Servers, config.Server)
+	if err != nil {
+		return "", err
+	}
+	serverURL, err := server.ServerURL(config.ServerVariables)
+	if err != nil {
+		return "", err
+	}
+	if u, err := url.Parse(serverURL); err != nil || !u.IsAbs() {
+		return "", fmt.Errorf("server URL %q is not absolute, set a base URL instead", serverURL)
+	}
+	return serverURL, nil
+}
+
+// requestServerURL returns the URL of a request's own server, with its
+// variables set by the client's ServerVariables. Absolute URLs give way to
+// a configured base URL, so that clients pointed at a mock or sandbox send
+// every request there.
+func (c *BaseClient) requestServerURL(server Server) (string, error) {
+	serverURL, err := server.ServerURL(c.serverVariables)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve request server: %w", err)
+	}
+	if u, err := url.Parse(serverURL); c.baseURLSet && err == nil && u.IsAbs() {
+		return "", nil
+	}
+	return serverURL, nil
+}
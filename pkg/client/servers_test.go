@@ -0,0 +1,153 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+var testServers = []Server{
+	{
+		Name: "production",
+		URL:  "https://{region}.api.example.com/{version}",
+		Variables: map[string]ServerVariable{
+			"region":  {Default: "us", Enum: []string{"us", "eu"}},
+			"version": {Default: "v1"},
+		},
+	},
+	{Name: "sandbox", URL: "https://sandbox.example.com"},
+	{URL: "/local"},
+}
+
+func TestServer_ServerURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "defaults", want: "https://us.api.example.com/v1"},
+		{name: "variables", vars: map[string]string{"region": "eu", "version": "v2"}, want: "https://eu.api.example.com/v2"},
+		{name: "outside enum", vars: map[string]string{"region": "ap"}, wantErr: `invalid value "ap" for server variable "region", must be one of: us, eu`},
+		{name: "undeclared variable", vars: map[string]string{"zone": "a", "region": "eu"}, want: "https://eu.api.example.com/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testServers[0].ServerURL(tt.vars)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ServerURL() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ServerURL() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBaseClient_Servers(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    string
+		wantErr string
+	}{
+		{name: "first server", config: Config{Servers: testServers}, want: "https://us.api.example.com/v1/"},
+		{name: "by name", config: Config{Servers: testServers, Server: "Sandbox"}, want: "https://sandbox.example.com/"},
+		{name: "by index", config: Config{Servers: testServers, Server: "1"}, want: "https://sandbox.example.com/"},
+		{
+			name:   "with variables",
+			config: Config{Servers: testServers, ServerVariables: map[string]string{"region": "eu"}},
+			want:   "https://eu.api.example.com/v1/",
+		},
+		{name: "base URL first", config: Config{BaseURL: "http://localhost:8080", Servers: testServers, Server: "sandbox"}, want: "http://localhost:8080/"},
+		{name: "unknown server", config: Config{Servers: testServers, Server: "staging"}, wantErr: `unknown server "staging"`},
+		{name: "relative server", config: Config{Servers: testServers, Server: "2"}, wantErr: "is not absolute"},
+		{name: "invalid variable", config: Config{Servers: testServers, ServerVariables: map[string]string{"region": "ap"}}, wantErr: "invalid value"},
+		{
+			name:   "variables of another server",
+			config: Config{Servers: testServers, Server: "sandbox", ServerVariables: map[string]string{"region": "eu"}},
+			want:   "https://sandbox.example.com/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			client, err := NewBaseClient(&config)
+			if config.BaseURL != tt.config.BaseURL {
+				t.Errorf("NewBaseClient() changed the config's BaseURL to %q", config.BaseURL)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("NewBaseClient() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewBaseClient() error = %v", err)
+			}
+			if client.BaseURL() != tt.want {
+				t.Errorf("BaseURL() = %q, want %q", client.BaseURL(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseClient_RequestServer(t *testing.T) {
+	server := Server{
+		URL:       "https://{region}.uploads.example.com",
+		Variables: map[string]ServerVariable{"region": {Default: "us", Enum: []string{"us", "eu"}}},
+	}
+	tests := []struct {
+		name    string
+		config  Config
+		server  Server
+		setBase string
+		want    string
+		wantErr string
+	}{
+		{name: "default variables", config: Config{Servers: testServers}, server: server, want: "https://us.uploads.example.com/files"},
+		{
+			name:   "configured variables",
+			config: Config{Servers: testServers, ServerVariables: map[string]string{"region": "eu"}},
+			server: server,
+			want:   "https://eu.uploads.example.com/files",
+		},
+		{name: "configured base URL", config: Config{BaseURL: "http://localhost:8080"}, server: server, want: "http://localhost:8080/files"},
+		{name: "base URL set later", config: Config{Servers: testServers}, server: server, setBase: "http://mock", want: "http://mock/files"},
+		{name: "relative server", config: Config{BaseURL: "http://localhost:8080/api"}, server: Server{URL: "v2"}, want: "http://localhost:8080/api/v2/files"},
+		{
+			name:    "invalid variable",
+			config:  Config{Servers: testServers, Server: "sandbox", ServerVariables: map[string]string{"region": "ap"}},
+			server:  Server{URL: "https://{region}.example.com", Variables: map[string]ServerVariable{"region": {Default: "eu", Enum: []string{"eu"}}}},
+			wantErr: "failed to resolve request server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewBaseClient(&tt.config)
+			if err != nil {
+				t.Fatalf("NewBaseClient() error = %v", err)
+			}
+			if tt.setBase != "" {
+				client.SetBaseURL(tt.setBase)
+			}
+			requestConfig := &RequestConfig{}
+			WithServer(tt.server)(requestConfig)
+			got, err := client.buildURL("files", requestConfig)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("buildURL() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("buildURL() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// StreamResponse is a response whose body is read as it arrives instead of
+// being buffered in memory. It must be closed once read.
+type StreamResponse struct {
+	StatusCode int
+	Headers    map[string][]string
+	// Body streams the response body
+	Body io.ReadCloser
+	// ContentType is the media type of the body, without parameters
+	ContentType string
+	// ContentLength is the length of the body in bytes, or -1 when unknown
+	ContentLength int64
+	// Filename is the file name suggested by the Content-Disposition header,
+	// stripped of any directory, or "" when none is given
+	Filename string
+}
+
+// Read reads from the response body
+func (r *StreamResponse) Read(p []byte) (int, error) {
+	return r.Body.Read(p)
+}
+
+// Close closes the response body
+func (r *StreamResponse) Close() error {
+	return r.Body.Close()
+}
+
+// StreamRequester is implemented by clients streaming response bodies, such
+// as *BaseClient
+type StreamRequester interface {
+	// RequestStream makes a request and returns the response body unread, for streaming
+	RequestStream(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*StreamResponse, error)
+}
+
+var _ StreamRequester = (*BaseClient)(nil)
+
+// RequestStream makes a request like Request but returns the response body
+// unread, so downloads of any size are never held in memory. An io.Reader
+// body is sent as is; other bodies are encoded by their content type, like
+// RequestMultipart and RequestForm do for multipart/form-data and
+// application/x-www-form-urlencoded, and like RequestEncoded otherwise.
+// Error responses are read and returned as *APIError.
+func (c *BaseClient) RequestStream(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*StreamResponse, error) {
+	var bodyReader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		bodyReader = b
+	default:
+		config := &RequestConfig{}
+		for _, opt := range opts {
+			opt(config)
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(config.ContentType)
+		switch mediaType {
+		case "multipart/form-data":
+			pr, contentType, err := c.multipartBody(body, config.PartEncodings)
+			if err != nil {
+				return nil, err
+			}
+			defer func() {
+				_ = pr.Close()
+			}()
+			bodyReader = pr
+			opts = append(opts, WithContentType(contentType))
+		case "application/x-www-form-urlencoded":
+			var err error
+			if bodyReader, err = c.formBody(body, config.PartEncodings); err != nil {
+				return nil, err
+			}
+		default:
+			var err error
+			if bodyReader, err = c.encodedBody(body, config.ContentType); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resp, err := c.send(ctx, method, path, bodyReader, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Error responses are small enough to read and parse
+	if resp.StatusCode >= 400 {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, c.parseError(&Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       respBody,
+		})
+	}
+
+	return newStreamResponse(resp), nil
+}
+
+// newStreamResponse wraps an HTTP response, extracting its body's metadata
+func newStreamResponse(resp *http.Response) *StreamResponse {
+	stream := &StreamResponse{
+		StatusCode:    resp.StatusCode,
+		Headers:       resp.Header,
+		Body:          resp.Body,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}
+	if mediaType, _, err := mime.ParseMediaType(stream.ContentType); err == nil {
+		stream.ContentType = mediaType
+	}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		stream.Filename = dispositionFilename(params["filename"])
+	}
+	return stream
+}
+
+// dispositionFilename strips the directory from a Content-Disposition file
+// name, so it is safe to use as a local path
+func dispositionFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	if filename == "." || filename == "/" || filename == ".." {
+		return ""
+	}
+	return filename
+}
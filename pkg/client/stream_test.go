@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestBaseClient_RequestStream(t *testing.T) {
+	body := &closeTracker{Reader: strings.NewReader("%PDF-1.7")}
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Accept"); got != "application/pdf" {
+				t.Errorf("expected Accept application/pdf, got %s", got)
+			}
+			header := make(http.Header)
+			header.Set("Content-Type", "application/pdf; name=report")
+			header.Set("Content-Disposition", `attachment; filename="../reports/q1.pdf"`)
+			return &http.Response{StatusCode: 200, Header: header, Body: body, ContentLength: 8}, nil
+		}},
+	}
+
+	resp, err := client.RequestStream(context.Background(), "GET", "reports/q1", nil, WithAccept("application/pdf"))
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	if body.closed {
+		t.Fatal("RequestStream() closed the body before it was read")
+	}
+	if resp.ContentType != "application/pdf" || resp.ContentLength != 8 || resp.Filename != "q1.pdf" {
+		t.Errorf("unexpected metadata: %q %d %q", resp.ContentType, resp.ContentLength, resp.Filename)
+	}
+	data, err := io.ReadAll(resp)
+	if err != nil || string(data) != "%PDF-1.7" {
+		t.Errorf("read %q, %v", data, err)
+	}
+	if err := resp.Close(); err != nil || !body.closed {
+		t.Errorf("Close() = %v, closed = %v", err, body.closed)
+	}
+}
+
+func TestBaseClient_RequestStreamBodies(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            interface{}
+		opts            []RequestOption
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "JSON",
+			body:            map[string]string{"format": "csv"},
+			wantContentType: "application/json",
+			wantBody:        `{"format":"csv"}`,
+		},
+		{
+			name:            "form",
+			body:            tokenRequest{GrantType: "client_credentials"},
+			opts:            []RequestOption{WithContentType("application/x-www-form-urlencoded")},
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "grant_type=client_credentials",
+		},
+		{
+			name:            "reader",
+			body:            strings.NewReader("raw"),
+			opts:            []RequestOption{WithContentType("text/csv")},
+			wantContentType: "text/csv",
+			wantBody:        "raw",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &BaseClient{
+				baseURL: "https://api.example.com/",
+				httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+					if got := req.Header.Get("Content-Type"); got != tt.wantContentType {
+						t.Errorf("expected Content-Type %s, got %s", tt.wantContentType, got)
+					}
+					data, _ := io.ReadAll(req.Body)
+					if string(data) != tt.wantBody {
+						t.Errorf("unexpected body %q", data)
+					}
+					return mockResponse(200, "ok"), nil
+				}},
+			}
+
+			resp, err := client.RequestStream(context.Background(), "POST", "exports", tt.body, tt.opts...)
+			if err != nil {
+				t.Fatalf("RequestStream() error = %v", err)
+			}
+			_ = resp.Close()
+		})
+	}
+}
+
+func TestBaseClient_RequestStreamMultipart(t *testing.T) {
+	var parts []multipartPart
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			parts = readParts(t, req)
+			return mockResponse(200, "ok"), nil
+		}},
+	}
+
+	resp, err := client.RequestStream(context.Background(), "POST", "convert", uploadRequest{
+		Avatar: File{Reader: strings.NewReader("png data"), Name: "me.png"},
+	}, WithContentType("multipart/form-data"))
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	_ = resp.Close()
+
+	if len(parts) != 1 || parts[0].filename != "me.png" || parts[0].content != "png data" {
+		t.Errorf("unexpected parts %+v", parts)
+	}
+}
+
+func TestBaseClient_RequestStreamError(t *testing.T) {
+	client := &BaseClient{
+		baseURL: "https://api.example.com/",
+		httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(404, `{"message":"export not found"}`), nil
+		}},
+	}
+
+	resp, err := client.RequestStream(context.Background(), "GET", "exports/1", nil)
+	var apiErr *APIError
+	if resp != nil || !errors.As(err, &apiErr) {
+		t.Fatalf("RequestStream() = %v, %v, want *APIError", resp, err)
+	}
+	if apiErr.StatusCode != 404 || apiErr.Message != "export not found" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+}
+
+func TestDispositionFilename(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":        "report.pdf",
+		"../../etc/passwd":  "passwd",
+		`C:\exports\a.csv`:  "a.csv",
+		"":                  "",
+		"..":                "",
+		"/":                 "",
+		"exports/2024.json": "2024.json",
+	}
+	for in, want := range tests {
+		if got := dispositionFilename(in); got != want {
+			t.Errorf("dispositionFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
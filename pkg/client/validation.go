@@ -0,0 +1,126 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Validator is implemented by generated models that can check themselves
+// against the constraints declared in the schema
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError describes a single constraint violation
+type ValidationError struct {
+	// Path locates the invalid value using JSON names, e.g. "items[2].name"
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors aggregates all violations found while validating a value
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Add records a violation at path
+func (e *ValidationErrors) Add(path, format string, args ...interface{}) {
+	*e = append(*e, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Nest records the violations of a nested value, prefixing their paths with path
+func (e *ValidationErrors) Nest(path string, err error) {
+	switch nested := err.(type) {
+	case nil:
+	case ValidationErrors:
+		for _, v := range nested {
+			*e = append(*e, &ValidationError{Path: joinValidationPath(path, v.Path), Message: v.Message})
+		}
+	case *ValidationError:
+		*e = append(*e, &ValidationError{Path: joinValidationPath(path, nested.Path), Message: nested.Message})
+	default:
+		*e = append(*e, &ValidationError{Path: path, Message: err.Error()})
+	}
+}
+
+// Err returns nil when no violations were recorded, and e otherwise
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// joinValidationPath appends a nested path to its parent path
+func joinValidationPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// patternCache holds compiled schema patterns keyed by their source
+var patternCache sync.Map
+
+// MatchPattern reports whether value matches the regular expression pattern.
+// Compiled patterns are cached; a pattern that does not compile matches nothing.
+func MatchPattern(pattern, value string) bool {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp).MatchString(value)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	patternCache.Store(pattern, re)
+	return re.MatchString(value)
+}
+
+// IsMultipleOf reports whether value is a multiple of factor, allowing for
+// floating point rounding
+func IsMultipleOf(value, factor float64) bool {
+	if factor == 0 {
+		return true
+	}
+	quotient := value / factor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+
+// HasUniqueItems reports whether no two items of a slice encode to the same JSON
+func HasUniqueItems[T any](items []T) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		if seen[string(encoded)] {
+			return false
+		}
+		seen[string(encoded)] = true
+	}
+	return true
+}
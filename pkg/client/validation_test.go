@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestValidationErrors_Nest(t *testing.T) {
+	nested := ValidationErrors{
+		{Path: "name", Message: "is required"},
+		{Path: "[0]", Message: "too short"},
+		{Message: "invalid"},
+	}
+
+	var errs ValidationErrors
+	errs.Add("id", "must be at least %d characters", 3)
+	errs.Nest("owner", nested)
+	errs.Nest("status", &ValidationError{Message: "invalid Status value x"})
+	errs.Nest("other", errors.New("boom"))
+	errs.Nest("ignored", nil)
+
+	want := []string{
+		"id: must be at least 3 characters",
+		"owner.name: is required",
+		"owner[0]: too short",
+		"owner: invalid",
+		"status: invalid Status value x",
+		"other: boom",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, w := range want {
+		if got := errs[i].Error(); got != w {
+			t.Errorf("error %d = %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestValidationErrors_Err(t *testing.T) {
+	var errs ValidationErrors
+	if errs.Err() != nil {
+		t.Error("Err() of no violations should be nil")
+	}
+
+	errs.Add("a", "bad")
+	errs.Add("b", "worse")
+	err := errs.Err()
+	if err == nil || err.Error() != "a: bad; b: worse" {
+		t.Errorf("Err() = %v, want a: bad; b: worse", err)
+	}
+
+	var target ValidationErrors
+	if !errors.As(err, &target) || len(target) != 2 {
+		t.Errorf("errors.As() did not recover the violations from %v", err)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{`^[A-Z]{2}-\d+$`, "AB-12", true},
+		{`^[A-Z]{2}-\d+$`, "ab-12", false},
+		{`^[A-Z]{2}-\d+$`, "AB-12", true}, // served from the cache
+		{`(?!x)`, "y", false},             // unsupported syntax matches nothing
+	}
+
+	for _, tt := range tests {
+		if got := MatchPattern(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	tests := []struct {
+		value  float64
+		factor float64
+		want   bool
+	}{
+		{10, 2, true},
+		{9, 2, false},
+		{0.3, 0.1, true},
+		{0.35, 0.1, false},
+		{5, 0, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsMultipleOf(tt.value, tt.factor); got != tt.want {
+			t.Errorf("IsMultipleOf(%v, %v) = %v, want %v", tt.value, tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestHasUniqueItems(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	if !HasUniqueItems([]string{"a", "b"}) {
+		t.Error("distinct strings reported as duplicates")
+	}
+	if HasUniqueItems([]string{"a", "a"}) {
+		t.Error("duplicate strings not detected")
+	}
+	if HasUniqueItems([]item{{ID: 1}, {ID: 1}}) {
+		t.Error("duplicate objects not detected")
+	}
+}
+
+// validatedPayload is a request body with a single constraint
+type validatedPayload struct {
+	Name string `json:"name"`
+}
+
+func (p validatedPayload) Validate() error {
+	var errs ValidationErrors
+	if p.Name == "" {
+		errs.Add("name", "is required")
+	}
+	return errs.Err()
+}
+
+func TestBaseClient_RequestJSON_ValidateRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  bool
+		body      interface{}
+		wantErr   bool
+		wantCalls int
+	}{
+		{"invalid body rejected", true, validatedPayload{}, true, 0},
+		{"valid body sent", true, validatedPayload{Name: "x"}, false, 1},
+		{"validation disabled", false, validatedPayload{}, false, 1},
+		{"body without Validate", true, map[string]string{}, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := &BaseClient{
+				httpClient: &mockHTTPClient{doFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					return mockResponse(200, `{}`), nil
+				}},
+				baseURL:          "https://api.example.com/",
+				validateRequests: tt.validate,
+			}
+
+			_, err := client.RequestJSON(context.Background(), "POST", "items", tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RequestJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("sent %d requests, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
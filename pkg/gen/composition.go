@@ -0,0 +1,262 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// isObjectSchema reports whether a schema should be generated as a struct:
+// either an explicit object, or an untyped schema with properties or allOf
+func isObjectSchema(schema *openapi3.Schema) bool {
+	if schema.Type != nil && schema.Type.Is("object") {
+		return true
+	}
+	if schema.Type != nil && len(*schema.Type) > 0 {
+		return false
+	}
+	return len(schema.Properties) > 0 || len(schema.AllOf) > 0
+}
+
+// isStructuralSchema reports whether a schema contributes to the shape of a
+// value, as opposed to members that only add a description or nullable flag
+func isStructuralSchema(schemaRef *openapi3.SchemaRef) bool {
+	if schemaRef == nil {
+		return false
+	}
+	if schemaRef.Ref != "" {
+		return true
+	}
+	schema := schemaRef.Value
+	if schema == nil {
+		return false
+	}
+	return (schema.Type != nil && len(*schema.Type) > 0) ||
+		len(schema.Properties) > 0 ||
+		len(schema.AllOf) > 0 ||
+		len(schema.OneOf) > 0 ||
+		len(schema.AnyOf) > 0 ||
+		len(schema.Enum) > 0 ||
+		schema.Items != nil
+}
+
+// singleAllOfMember returns the only structural allOf member of a schema that
+// declares nothing else, as in the common `allOf: [$ref]` idiom used to attach
+// a description or nullable flag to a reference
+func singleAllOfMember(schema *openapi3.Schema) *openapi3.SchemaRef {
+	if len(schema.Properties) > 0 {
+		return nil
+	}
+
+	var found *openapi3.SchemaRef
+	for _, member := range schema.AllOf {
+		if !isStructuralSchema(member) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = member
+	}
+	return found
+}
+
+// mergeFields combines two definitions of the same property coming from
+// different allOf members. The property is required if any member requires it.
+// The later definition wins when it refines the earlier one, as in an object
+// schema replacing a free-form object; conflicting Go types fail generation.
+func (g *Generator) mergeFields(modelName string, earlier, later Field) Field {
+	merged := later
+	if merged.Description == "" {
+		merged.Description = earlier.Description
+	}
+	if earlier.Required {
+		merged.Required = true
+		merged.OmitEmpty = false
+	}
+
+	if conflictingTypes(earlier.Type, later.Type) && g.err == nil {
+		g.err = fmt.Errorf("%s.%s is defined as both %s and %s in allOf",
+			modelName, later.JSONName, earlier.Type, later.Type)
+	}
+
+	return merged
+}
+
+// conflictingTypes reports whether two definitions of a property can't be
+// merged. Types differing only in nullability don't conflict, nor does a
+// free-form object that the later definition refines.
+func conflictingTypes(earlier, later string) bool {
+	earlier, later = strings.TrimPrefix(earlier, "*"), strings.TrimPrefix(later, "*")
+	if earlier == later {
+		return false
+	}
+	return earlier != "interface{}" && earlier != "map[string]interface{}"
+}
+
+// warnf prints a generation warning when verbose output is enabled
+func (g *Generator) warnf(format string, args ...interface{}) {
+	if g.config != nil && g.config.Verbose {
+		fmt.Printf("Warning: "+format+"\n", args...)
+	}
+}
+
+// fillUnionModel turns a model into a union of the schema's oneOf (or anyOf)
+// members. Members mapping to the same Go type are collapsed into one variant.
+func (g *Generator) fillUnionModel(model *Model, schema *openapi3.Schema) {
+	model.IsUnion = true
+
+	members := schema.OneOf
+	if len(members) == 0 {
+		members = schema.AnyOf
+	}
+
+	usedNames := make(map[string]bool)
+	byType := make(map[string]int)
+	for i, member := range members {
+		if member == nil {
+			continue
+		}
+
+		scope := fmt.Sprintf("%sVariant%d", model.Name, i+1)
+		if member.Ref == "" && member.Value != nil && member.Value.Title != "" {
+			scope = toPascalCase(member.Value.Title)
+		}
+		goType := g.schemaRefToGoTypeInScope(member, "", scope)
+
+		if _, seen := byType[goType]; seen {
+			g.warnf("%s lists %s more than once; the duplicate variant is ignored", model.Name, goType)
+			continue
+		}
+
+		name := unionVariantName(goType)
+		for counter := 2; usedNames[name]; counter++ {
+			name = fmt.Sprintf("%s%d", unionVariantName(goType), counter)
+		}
+		usedNames[name] = true
+
+		byType[goType] = len(model.Variants)
+		variant := UnionVariant{Name: name, Type: goType}
+		if member.Value != nil && isObjectSchema(member.Value) {
+			variant.Required = objectRequiredNames(member.Value)
+		}
+		model.Variants = append(model.Variants, variant)
+	}
+
+	if schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+		return
+	}
+	model.Discriminator = schema.Discriminator.PropertyName
+
+	// Explicit mappings take precedence over the implicit schema name values
+	mapped := make(map[string]bool)
+	for _, value := range sortedStringMapKeys(schema.Discriminator.Mapping) {
+		ref := schema.Discriminator.Mapping[value]
+		goType := toPascalCase(ref[strings.LastIndex(ref, "/")+1:])
+		if i, ok := byType[goType]; ok {
+			model.Variants[i].DiscriminatorValues = append(model.Variants[i].DiscriminatorValues, value)
+			mapped[goType] = true
+		}
+	}
+	for _, member := range members {
+		if member == nil || member.Ref == "" {
+			continue
+		}
+		goType := g.schemaRefToGoType(member)
+		if i, ok := byType[goType]; ok && !mapped[goType] {
+			schemaName := member.Ref[strings.LastIndex(member.Ref, "/")+1:]
+			model.Variants[i].DiscriminatorValues = append(model.Variants[i].DiscriminatorValues, schemaName)
+		}
+	}
+}
+
+// objectRequiredNames returns the names of the properties an object schema
+// requires, including those required by its allOf members, in sorted order
+func objectRequiredNames(schema *openapi3.Schema) []string {
+	required := make(map[string]bool)
+	var collect func(*openapi3.Schema)
+	collect = func(s *openapi3.Schema) {
+		for _, member := range s.AllOf {
+			if member != nil && member.Value != nil {
+				collect(member.Value)
+			}
+		}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+	}
+	collect(schema)
+	return sortedKeys(required)
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringSliceLiteral returns the Go literal of a list of strings, or nil
+func stringSliceLiteral(values []string) string {
+	if len(values) == 0 {
+		return "nil"
+	}
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// unionVariantName derives an accessor name from a variant's Go type,
+// e.g. "Card", "String" or "StringList"
+func unionVariantName(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "[]"):
+		return unionVariantName(goType[2:]) + "List"
+	case strings.HasPrefix(goType, "map[string]"):
+		return unionVariantName(goType[len("map[string]"):]) + "Map"
+	case strings.HasPrefix(goType, "*"):
+		return unionVariantName(goType[1:])
+	case goType == "interface{}":
+		return "Any"
+	case goType == "time.Time":
+		return "Time"
+	}
+	return toPascalCase(goType)
+}
+
+// sortedStringMapKeys returns the keys of a string map in sorted order
+func sortedStringMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// needsUnionDecodeHelper reports whether any union is decoded by trying its variants in order
+func needsUnionDecodeHelper(models []Model) bool {
+	for _, model := range models {
+		if model.IsUnion && model.Discriminator == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// needsDiscriminatorHelper reports whether any union encodes a discriminator property
+func needsDiscriminatorHelper(models []Model) bool {
+	for _, model := range models {
+		if model.IsUnion && model.Discriminator != "" {
+			return true
+		}
+	}
+	return false
+}
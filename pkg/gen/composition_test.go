@@ -0,0 +1,553 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// loadTestSpec parses an OpenAPI document for tests that work on extracted models
+func loadTestSpec(t *testing.T, specContent string) *Generator {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData([]byte(specContent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spec.Validate(loader.Context); err != nil {
+		t.Fatal(err)
+	}
+	return &Generator{config: &Config{PackageName: "test"}, spec: spec}
+}
+
+// findModel returns the model with the given name or fails the test
+func findModel(t *testing.T, models []Model, name string) Model {
+	t.Helper()
+	for _, model := range models {
+		if model.Name == name {
+			return model
+		}
+	}
+	t.Fatalf("model %s not found", name)
+	return Model{}
+}
+
+// findField returns the field with the given JSON name or fails the test
+func findField(t *testing.T, model Model, jsonName string) Field {
+	t.Helper()
+	for _, field := range model.Fields {
+		if field.JSONName == jsonName {
+			return field
+		}
+	}
+	t.Fatalf("field %s.%s not found", model.Name, jsonName)
+	return Field{}
+}
+
+const allOfSpec = `
+openapi: 3.0.0
+info:
+  title: AllOf API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+          description: Pet name
+        tag:
+          type: object
+    Dog:
+      description: A dog
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          required: [name]
+          properties:
+            breed:
+              type: string
+            tag:
+              $ref: '#/components/schemas/Tag'
+    Tag:
+      type: object
+      properties:
+        label:
+          type: string
+    Owner:
+      type: object
+      properties:
+        pet:
+          nullable: true
+          description: The pet
+          allOf:
+            - $ref: '#/components/schemas/Pet'
+        combo:
+          allOf:
+            - $ref: '#/components/schemas/Tag'
+            - type: object
+              properties:
+                extra:
+                  type: integer
+`
+
+func TestAllOfFlattensMembers(t *testing.T) {
+	gen := loadTestSpec(t, allOfSpec)
+	models := gen.extractModels()
+
+	dog := findModel(t, models, "Dog")
+	if dog.Description != "A dog" {
+		t.Errorf("Dog description = %q, want %q", dog.Description, "A dog")
+	}
+
+	wantOrder := []string{"id", "name", "tag", "breed"}
+	if len(dog.Fields) != len(wantOrder) {
+		t.Fatalf("Dog has %d fields, want %d", len(dog.Fields), len(wantOrder))
+	}
+	for i, name := range wantOrder {
+		if dog.Fields[i].JSONName != name {
+			t.Errorf("Dog field %d = %s, want %s", i, dog.Fields[i].JSONName, name)
+		}
+	}
+
+	if !findField(t, dog, "id").Required {
+		t.Error("Dog.id should be required by the Pet member")
+	}
+	name := findField(t, dog, "name")
+	if !name.Required || name.OmitEmpty {
+		t.Error("Dog.name should be required by the inline member")
+	}
+	if name.Description != "Pet name" {
+		t.Errorf("Dog.name description = %q, want %q", name.Description, "Pet name")
+	}
+	if findField(t, dog, "breed").Required {
+		t.Error("Dog.breed should be optional")
+	}
+}
+
+func TestAllOfRefinedPropertiesUseLaterMember(t *testing.T) {
+	gen := loadTestSpec(t, allOfSpec)
+	dog := findModel(t, gen.extractModels(), "Dog")
+
+	if got := findField(t, dog, "tag").Type; got != "Tag" {
+		t.Errorf("Dog.tag type = %s, want Tag", got)
+	}
+	if gen.err != nil {
+		t.Errorf("refining a free-form object should not fail: %v", gen.err)
+	}
+}
+
+func TestAllOfConflictingPropertiesFail(t *testing.T) {
+	gen := loadTestSpec(t, `
+openapi: 3.0.0
+info:
+  title: AllOf API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: string
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          properties:
+            id:
+              type: integer
+`)
+	gen.extractModels()
+
+	want := "Dog.id is defined as both string and int64 in allOf"
+	if gen.err == nil || gen.err.Error() != want {
+		t.Errorf("err = %v, want %q", gen.err, want)
+	}
+}
+
+func TestConflictingTypes(t *testing.T) {
+	tests := []struct {
+		earlier, later string
+		want           bool
+	}{
+		{"string", "string", false},
+		{"string", "*string", false},
+		{"map[string]interface{}", "Tag", false},
+		{"interface{}", "*Tag", false},
+		{"string", "int64", true},
+		{"Tag", "map[string]interface{}", true},
+	}
+	for _, tt := range tests {
+		if got := conflictingTypes(tt.earlier, tt.later); got != tt.want {
+			t.Errorf("conflictingTypes(%q, %q) = %v, want %v", tt.earlier, tt.later, got, tt.want)
+		}
+	}
+}
+
+func TestAllOfInProperties(t *testing.T) {
+	gen := loadTestSpec(t, allOfSpec)
+	models := gen.extractModels()
+	owner := findModel(t, models, "Owner")
+
+	if got := findField(t, owner, "pet").Type; got != "*Pet" {
+		t.Errorf("Owner.pet type = %s, want *Pet", got)
+	}
+
+	if got := findField(t, owner, "combo").Type; got != "OwnerCombo" {
+		t.Fatalf("Owner.combo type = %s, want OwnerCombo", got)
+	}
+	combo := findModel(t, models, "OwnerCombo")
+	findField(t, combo, "label")
+	findField(t, combo, "extra")
+}
+
+func TestSingleAllOfMember(t *testing.T) {
+	ref := &openapi3.SchemaRef{Ref: "#/components/schemas/Pet", Value: &openapi3.Schema{}}
+	descriptionOnly := &openapi3.SchemaRef{Value: &openapi3.Schema{Description: "docs"}}
+	objectType := openapi3.Types{"object"}
+	inline := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &objectType}}
+
+	if got := singleAllOfMember(&openapi3.Schema{AllOf: openapi3.SchemaRefs{ref, descriptionOnly}}); got != ref {
+		t.Error("singleAllOfMember() should ignore members without structure")
+	}
+	if got := singleAllOfMember(&openapi3.Schema{AllOf: openapi3.SchemaRefs{ref, inline}}); got != nil {
+		t.Error("singleAllOfMember() should return nil for several structural members")
+	}
+}
+
+const unionSpec = `
+openapi: 3.0.0
+info:
+  title: Union API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Card:
+      type: object
+      properties:
+        kind:
+          type: string
+    BankAccount:
+      type: object
+      properties:
+        kind:
+          type: string
+    PaymentMethod:
+      oneOf:
+        - $ref: '#/components/schemas/Card'
+        - $ref: '#/components/schemas/BankAccount'
+      discriminator:
+        propertyName: kind
+        mapping:
+          card: '#/components/schemas/Card'
+          bank: '#/components/schemas/BankAccount'
+    ImplicitMethod:
+      oneOf:
+        - $ref: '#/components/schemas/Card'
+        - $ref: '#/components/schemas/BankAccount'
+      discriminator:
+        propertyName: kind
+    Event:
+      type: object
+      properties:
+        payload:
+          anyOf:
+            - type: string
+            - type: integer
+            - type: array
+              items:
+                type: string
+`
+
+func TestUnionWithDiscriminatorMapping(t *testing.T) {
+	gen := loadTestSpec(t, unionSpec)
+	method := findModel(t, gen.extractModels(), "PaymentMethod")
+
+	if !method.IsUnion {
+		t.Fatal("PaymentMethod should be a union")
+	}
+	if method.Discriminator != "kind" {
+		t.Errorf("PaymentMethod discriminator = %q, want kind", method.Discriminator)
+	}
+
+	want := []UnionVariant{
+		{Name: "Card", Type: "Card", DiscriminatorValues: []string{"card"}},
+		{Name: "BankAccount", Type: "BankAccount", DiscriminatorValues: []string{"bank"}},
+	}
+	if len(method.Variants) != len(want) {
+		t.Fatalf("PaymentMethod has %d variants, want %d", len(method.Variants), len(want))
+	}
+	for i, variant := range method.Variants {
+		if variant.Name != want[i].Name || variant.Type != want[i].Type {
+			t.Errorf("variant %d = %s (%s), want %s (%s)", i, variant.Name, variant.Type, want[i].Name, want[i].Type)
+		}
+		if strings.Join(variant.DiscriminatorValues, ",") != strings.Join(want[i].DiscriminatorValues, ",") {
+			t.Errorf("variant %s discriminator values = %v, want %v", variant.Name, variant.DiscriminatorValues, want[i].DiscriminatorValues)
+		}
+	}
+}
+
+func TestUnionWithImplicitDiscriminatorMapping(t *testing.T) {
+	gen := loadTestSpec(t, unionSpec)
+	method := findModel(t, gen.extractModels(), "ImplicitMethod")
+
+	for _, variant := range method.Variants {
+		if len(variant.DiscriminatorValues) != 1 || variant.DiscriminatorValues[0] != variant.Type {
+			t.Errorf("variant %s discriminator values = %v, want [%s]", variant.Name, variant.DiscriminatorValues, variant.Type)
+		}
+	}
+}
+
+func TestInlineAnyOfBecomesUnion(t *testing.T) {
+	gen := loadTestSpec(t, unionSpec)
+	models := gen.extractModels()
+
+	event := findModel(t, models, "Event")
+	if got := findField(t, event, "payload").Type; got != "EventPayload" {
+		t.Fatalf("Event.payload type = %s, want EventPayload", got)
+	}
+
+	payload := findModel(t, models, "EventPayload")
+	var names []string
+	for _, variant := range payload.Variants {
+		names = append(names, variant.Name)
+	}
+	if got := strings.Join(names, ","); got != "String,Int64,StringList" {
+		t.Errorf("EventPayload variants = %s, want String,Int64,StringList", got)
+	}
+	if payload.Discriminator != "" {
+		t.Error("EventPayload should not have a discriminator")
+	}
+}
+
+func TestGenerateUnionModels(t *testing.T) {
+	files := generateFromSpec(t, unionSpec, &Config{GenerateModels: true})
+	modelsStr := files["models.go"]
+
+	expected := []string{
+		"func (u PaymentMethod) AsCard() (Card, error)",
+		"func (u *PaymentMethod) FromBankAccount(v BankAccount)",
+		"func (u PaymentMethod) MarshalJSON() ([]byte, error)",
+		"func (u *PaymentMethod) UnmarshalJSON(data []byte) error",
+		`case "card":`,
+		`return setUnionDiscriminator(data, "kind", "bank")`,
+		"func (u EventPayload) AsStringList() ([]string, error)",
+		"unionVariantFits(data, &v, nil)",
+		`"encoding/json"`,
+		`"slices"`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(modelsStr, exp) {
+			t.Errorf("models.go should contain %q", exp)
+		}
+	}
+	// Unknown fields don't rule out variants
+	for _, unexpected := range []string{"DisallowUnknownFields"} {
+		if strings.Contains(modelsStr, unexpected) {
+			t.Errorf("models.go should not contain %q", unexpected)
+		}
+	}
+}
+
+func TestUnionVariantRequiredProperties(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Pets API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          required: [bark]
+          properties:
+            bark:
+              type: boolean
+    Cat:
+      type: object
+      properties:
+        meow:
+          type: boolean
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+        - type: string
+`
+	gen := loadTestSpec(t, specContent)
+	pet := findModel(t, gen.extractModels(), "Pet")
+
+	want := []string{"bark,name", "", ""}
+	if len(pet.Variants) != len(want) {
+		t.Fatalf("Pet has %d variants, want %d", len(pet.Variants), len(want))
+	}
+	for i, variant := range pet.Variants {
+		if got := strings.Join(variant.Required, ","); got != want[i] {
+			t.Errorf("variant %s required = %s, want %s", variant.Name, got, want[i])
+		}
+	}
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true})
+	for _, exp := range []string{
+		// Variants are tried in declaration order
+		"if unionVariantFits(data, &v, []string{\"bark\", \"name\"}) {\n\t\t\tu.value = v\n\t\t\treturn nil\n\t\t}\n\t}\n\t{\n\t\tvar v Cat\n\t\tif unionVariantFits(data, &v, nil) {",
+		`return fmt.Errorf("data does not match any Pet variant")`,
+	} {
+		if !strings.Contains(files["models.go"], exp) {
+			t.Errorf("models.go should contain %q", exp)
+		}
+	}
+}
+
+const inlineObjectSpec = `
+openapi: 3.0.0
+info:
+  title: Inline API
+  version: 1.0.0
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                lines:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      sku:
+                        type: string
+      responses:
+        '200':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: string
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+    UserAddress2:
+      type: object
+      properties:
+        zip:
+          type: string
+    Team:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            street:
+              type: string
+        labels:
+          type: object
+          additionalProperties:
+            type: object
+            properties:
+              color:
+                type: string
+`
+
+func TestInlineObjectsBecomeNamedModels(t *testing.T) {
+	gen := loadTestSpec(t, inlineObjectSpec)
+	models := gen.extractModels()
+	operations := gen.extractOperations()
+	models = append(models, gen.takeInlineModels()...)
+
+	tests := []struct {
+		model string
+		field string
+		want  string
+	}{
+		{"User", "address", "UserAddress"},
+		{"UserAddress", "city", "string"},
+		{"Team", "address", "TeamAddress"},
+		{"Team", "labels", "map[string]TeamLabelsValue"},
+		{"TeamLabelsValue", "color", "string"},
+		{"CreateOrderRequest", "lines", "[]CreateOrderRequestLinesItem"},
+		{"CreateOrderRequestLinesItem", "sku", "string"},
+		{"CreateOrderResponse", "id", "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model+"."+tt.field, func(t *testing.T) {
+			field := findField(t, findModel(t, models, tt.model), tt.field)
+			if field.Type != tt.want {
+				t.Errorf("type = %s, want %s", field.Type, tt.want)
+			}
+		})
+	}
+
+	if len(operations) != 1 {
+		t.Fatalf("got %d operations, want 1", len(operations))
+	}
+	if got := operations[0].RequestBody.Type; got != "CreateOrderRequest" {
+		t.Errorf("request body type = %s, want CreateOrderRequest", got)
+	}
+	if got := operations[0].SuccessResponse.Type; got != "CreateOrderResponse" {
+		t.Errorf("success response type = %s, want CreateOrderResponse", got)
+	}
+}
+
+func TestInlineModelNamesAvoidCollisions(t *testing.T) {
+	spec := strings.Replace(inlineObjectSpec, "    UserAddress2:", "    UserAddress:", 1)
+	spec = strings.Replace(spec, "    Team:", "    CreateOrderRequest:\n      type: object\n    Team:", 1)
+
+	var first []string
+	for run := 0; run < 3; run++ {
+		gen := loadTestSpec(t, spec)
+		models := gen.extractModels()
+		operations := gen.extractOperations()
+		models = append(models, gen.takeInlineModels()...)
+
+		if got := findField(t, findModel(t, models, "User"), "address").Type; got != "UserAddress2" {
+			t.Errorf("User.address type = %s, want UserAddress2", got)
+		}
+		if got := operations[0].RequestBody.Type; got != "CreateOrderRequest2" {
+			t.Errorf("request body type = %s, want CreateOrderRequest2", got)
+		}
+
+		var names []string
+		for _, model := range models {
+			names = append(names, model.Name)
+		}
+		if run == 0 {
+			first = names
+		} else if strings.Join(names, ",") != strings.Join(first, ",") {
+			t.Errorf("model names changed between runs: %v vs %v", first, names)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package gen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EnumValue represents one declared value of an enum model
+type EnumValue struct {
+	// Name is the constant name, already prefixed with the enum type name
+	Name string
+	// Literal is the value as a Go literal, e.g. "active" or 3
+	Literal     string
+	Description string
+}
+
+// enumBaseType returns the Go type underlying an enum schema
+func enumBaseType(schema *openapi3.Schema) string {
+	switch {
+	case schema.Type.Is("integer"):
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case schema.Type.Is("number"):
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case schema.Type.Is("boolean"):
+		return "bool"
+	case schema.Type.Is("string"):
+		return "string"
+	}
+
+	// Untyped enums take the type of their first value
+	for _, v := range schema.Enum {
+		switch v.(type) {
+		case string:
+			return "string"
+		case float64, int, int64:
+			return "float64"
+		case bool:
+			return "bool"
+		}
+	}
+	return "string"
+}
+
+// fillEnumModel fills in the type and values of an enum model. Constant names
+// come from x-enum-varnames when present, otherwise from the values themselves.
+func (g *Generator) fillEnumModel(model *Model, schema *openapi3.Schema) {
+	model.IsEnum = true
+	model.EnumType = enumBaseType(schema)
+
+	varNames := stringListExtension(schema, "x-enum-varnames")
+	descriptions := stringListExtension(schema, "x-enum-descriptions")
+
+	usedNames := make(map[string]bool)
+	seenLiterals := make(map[string]bool)
+	for i, v := range schema.Enum {
+		if v == nil {
+			// A null value only marks the enum as nullable
+			continue
+		}
+		literal, ok := enumLiteral(model.EnumType, v)
+		if !ok {
+			g.warnf("enum %s: skipping value %v, not a %s", model.Name, v, model.EnumType)
+			continue
+		}
+		if seenLiterals[literal] {
+			continue
+		}
+		seenLiterals[literal] = true
+
+		suffix := ""
+		if i < len(varNames) && varNames[i] != "" {
+			suffix = toPascalCase(varNames[i])
+		}
+		if suffix == "" {
+			suffix = enumConstantSuffix(v)
+		}
+
+		baseName := model.Name + suffix
+		name := baseName
+		for counter := 2; usedNames[name]; counter++ {
+			name = fmt.Sprintf("%s%d", baseName, counter)
+		}
+		usedNames[name] = true
+
+		value := EnumValue{Name: name, Literal: literal}
+		if i < len(descriptions) {
+			value.Description = descriptions[i]
+		}
+		model.EnumValues = append(model.EnumValues, value)
+	}
+}
+
+// enumLiteral formats an enum value as a Go literal of the given base type
+func enumLiteral(baseType string, v interface{}) (string, bool) {
+	switch baseType {
+	case "string":
+		s, ok := v.(string)
+		return strconv.Quote(s), ok
+	case "bool":
+		b, ok := v.(bool)
+		return strconv.FormatBool(b), ok
+	}
+
+	var f float64
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	default:
+		return "", false
+	}
+	if strings.HasPrefix(baseType, "int") && f != float64(int64(f)) {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), true
+}
+
+// enumConstantSuffix derives a constant name suffix from an enum value
+func enumConstantSuffix(v interface{}) string {
+	var s string
+	switch n := v.(type) {
+	case string:
+		s = n
+	case float64:
+		s = strconv.FormatFloat(n, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(n)
+	default:
+		s = fmt.Sprint(n)
+	}
+
+	if s == "" {
+		return "Empty"
+	}
+
+	prefix := ""
+	switch s[0] {
+	case '-':
+		prefix, s = "Minus", s[1:]
+	case '+':
+		prefix, s = "Plus", s[1:]
+	}
+	s = strings.ReplaceAll(s, ".", " point ")
+
+	name := prefix + toPascalCase(s)
+	if name == "" {
+		return "Value"
+	}
+	return name
+}
+
+// stringListExtension returns a schema extension holding a list of strings
+func stringListExtension(schema *openapi3.Schema, key string) []string {
+	raw, ok := schema.Extensions[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, len(raw))
+	for i, v := range raw {
+		if s, ok := v.(string); ok {
+			values[i] = s
+		}
+	}
+	return values
+}
+
+// enumParseCall returns the strconv call parsing a string named s into an
+// enum's underlying type, or "" for string enums
+func enumParseCall(enumType string) string {
+	switch enumType {
+	case "int32":
+		return "strconv.ParseInt(s, 10, 32)"
+	case "int64":
+		return "strconv.ParseInt(s, 10, 64)"
+	case "float32":
+		return "strconv.ParseFloat(s, 32)"
+	case "float64":
+		return "strconv.ParseFloat(s, 64)"
+	case "bool":
+		return "strconv.ParseBool(s)"
+	}
+	return ""
+}
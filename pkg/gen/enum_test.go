@@ -0,0 +1,167 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnumConstantSuffix(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"active", "Active"},
+		{"in-progress", "InProgress"},
+		{"", "Empty"},
+		{"+1", "Plus1"},
+		{"-1", "Minus1"},
+		{float64(3), "3"},
+		{float64(-2), "Minus2"},
+		{1.5, "1Point5"},
+		{true, "True"},
+		{"!!", "Value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := enumConstantSuffix(tt.value); got != tt.want {
+				t.Errorf("enumConstantSuffix(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnumParseCall(t *testing.T) {
+	tests := map[string]string{
+		"string":  "",
+		"int32":   "strconv.ParseInt(s, 10, 32)",
+		"int64":   "strconv.ParseInt(s, 10, 64)",
+		"float64": "strconv.ParseFloat(s, 64)",
+		"bool":    "strconv.ParseBool(s)",
+	}
+	for enumType, want := range tests {
+		if got := enumParseCall(enumType); got != want {
+			t.Errorf("enumParseCall(%q) = %q, want %q", enumType, got, want)
+		}
+	}
+}
+
+const enumSpec = `
+openapi: 3.0.0
+info:
+  title: Enum API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [in-progress, in_progress, '', '+1', done, done]
+    Priority:
+      type: integer
+      format: int32
+      enum: [1, 2]
+      x-enum-varnames: [low, high]
+      x-enum-descriptions: [Low priority, High priority]
+    Ratio:
+      type: number
+      enum: [0.5, -2]
+    Task:
+      type: object
+      properties:
+        kind:
+          type: string
+          nullable: true
+          enum: [bug, feature, null]
+`
+
+func TestEnumModels(t *testing.T) {
+	gen := loadTestSpec(t, enumSpec)
+	models := gen.extractModels()
+
+	tests := []struct {
+		model    string
+		enumType string
+		values   []EnumValue
+	}{
+		{
+			model:    "Status",
+			enumType: "string",
+			values: []EnumValue{
+				{Name: "StatusInProgress", Literal: `"in-progress"`},
+				{Name: "StatusInProgress2", Literal: `"in_progress"`},
+				{Name: "StatusEmpty", Literal: `""`},
+				{Name: "StatusPlus1", Literal: `"+1"`},
+				{Name: "StatusDone", Literal: `"done"`},
+			},
+		},
+		{
+			model:    "Priority",
+			enumType: "int32",
+			values: []EnumValue{
+				{Name: "PriorityLow", Literal: "1", Description: "Low priority"},
+				{Name: "PriorityHigh", Literal: "2", Description: "High priority"},
+			},
+		},
+		{
+			model:    "Ratio",
+			enumType: "float64",
+			values: []EnumValue{
+				{Name: "Ratio0Point5", Literal: "0.5"},
+				{Name: "RatioMinus2", Literal: "-2"},
+			},
+		},
+		{
+			model:    "TaskKind",
+			enumType: "string",
+			values: []EnumValue{
+				{Name: "TaskKindBug", Literal: `"bug"`},
+				{Name: "TaskKindFeature", Literal: `"feature"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			model := findModel(t, models, tt.model)
+			if !model.IsEnum {
+				t.Fatalf("%s is not an enum", tt.model)
+			}
+			if model.EnumType != tt.enumType {
+				t.Errorf("EnumType = %s, want %s", model.EnumType, tt.enumType)
+			}
+			if len(model.EnumValues) != len(tt.values) {
+				t.Fatalf("got %d values, want %d: %+v", len(model.EnumValues), len(tt.values), model.EnumValues)
+			}
+			for i, want := range tt.values {
+				if model.EnumValues[i] != want {
+					t.Errorf("value %d = %+v, want %+v", i, model.EnumValues[i], want)
+				}
+			}
+		})
+	}
+
+	if got := findField(t, findModel(t, models, "Task"), "kind").Type; got != "*TaskKind" {
+		t.Errorf("Task.kind type = %s, want *TaskKind", got)
+	}
+}
+
+func TestGenerateEnumModels(t *testing.T) {
+	files := generateFromSpec(t, enumSpec, &Config{GenerateModels: true})
+	models := files["models.go"]
+
+	for _, want := range []string{
+		"type Priority int32",
+		"// Low priority\n\tPriorityLow Priority = 1",
+		"func (Status) Values() []Status {",
+		"func (e Status) IsValid() bool {",
+		"func ParseStatus(s string) (Status, error) {",
+		"v, err := strconv.ParseInt(s, 10, 32)",
+		"v, err := strconv.ParseFloat(s, 64)",
+		"Kind *TaskKind `json:\"kind,omitempty\"`",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("models.go missing %q", want)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -42,6 +44,11 @@ type Config struct {
 	GenerateClient bool
 	// EmbedClient indicates whether to copy client packages instead of importing
 	EmbedClient bool
+	// GenerateValidation adds Validate methods checking schema constraints to models
+	GenerateValidation bool
+	// GroupByTag generates the operations of each tag, or of each path
+	// prefix when untagged, on a sub-client of their own
+	GroupByTag bool
 	// Verbose enables verbose output
 	Verbose bool
 }
@@ -51,6 +58,18 @@ type Generator struct {
 	config    *Config
 	spec      *openapi3.T
 	templates *template.Template
+	// inline tracks models synthesized from inline schemas
+	inline inlineRegistry
+	// multipartSchemas holds the schemas of multipart request bodies
+	multipartSchemas map[*openapi3.Schema]bool
+	// multipartCopies maps component schemas also used outside multipart
+	// bodies to the copies their multipart body models are built from
+	multipartCopies map[*openapi3.Schema]*openapi3.Schema
+	// xmlTags adds xml struct tags to models of specs declaring XML content
+	xmlTags bool
+	// err is the first error found while extracting models and operations,
+	// such as conflicting allOf members, which fails generation
+	err error
 }
 
 // NewGenerator creates a new code generator
@@ -128,10 +147,15 @@ func (g *Generator) loadTemplates() error {
 
 // LoadSpec loads and validates the OpenAPI specification
 func (g *Generator) LoadSpec() error {
+	data, err := os.ReadFile(g.config.SpecPath)
+	if err != nil {
+		return fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
 
-	spec, err := loader.LoadFromFile(g.config.SpecPath)
+	spec, err := loadDocument(loader, data, g.config.SpecPath)
 	if err != nil {
 		return fmt.Errorf("failed to load OpenAPI spec: %w", err)
 	}
@@ -157,16 +181,25 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Extract everything up front: operations may synthesize models from
+	// inline request and response schemas, which belong in the models file
+	models := g.extractModels()
+	operations := g.extractOperations()
+	models = append(models, g.takeInlineModels()...)
+	if g.err != nil {
+		return fmt.Errorf("failed to extract models: %w", g.err)
+	}
+
 	// Generate models
 	if g.config.GenerateModels {
-		if err := g.generateModels(); err != nil {
+		if err := g.generateModels(models); err != nil {
 			return fmt.Errorf("failed to generate models: %w", err)
 		}
 	}
 
 	// Generate client
 	if g.config.GenerateClient {
-		if err := g.generateClient(); err != nil {
+		if err := g.generateClient(operations); err != nil {
 			return fmt.Errorf("failed to generate client: %w", err)
 		}
 	}
@@ -175,14 +208,28 @@ func (g *Generator) Generate() error {
 }
 
 // generateModels generates model files from OpenAPI schemas
-func (g *Generator) generateModels() error {
-	// Prepare model data
-	models := g.extractModels()
+func (g *Generator) generateModels(models []Model) error {
+	// Models are emitted in name order, keeping inline models next to their parents
+	sort.SliceStable(models, func(i, j int) bool {
+		return models[i].Name < models[j].Name
+	})
+
+	if g.config.GenerateValidation {
+		for i := range models {
+			if !models[i].IsEnum && !models[i].IsUnion {
+				models[i].Validation = g.structValidation(models[i])
+			}
+		}
+	}
 
+	// Prepare model data
 	data := map[string]interface{}{
-		"Package": g.config.ModelPackage,
-		"Models":  models,
-		"Imports": g.getModelImports(models),
+		"Package":                  g.config.ModelPackage,
+		"Models":                   models,
+		"Imports":                  g.getModelImports(models),
+		"NeedsUnionDecodeHelper":   needsUnionDecodeHelper(models),
+		"NeedsDiscriminatorHelper": needsDiscriminatorHelper(models),
+		"GenerateValidation":       g.config.GenerateValidation,
 	}
 
 	// Generate models file
@@ -200,23 +247,74 @@ func (g *Generator) generateModels() error {
 }
 
 // generateClient generates client files from OpenAPI paths
-func (g *Generator) generateClient() error {
-	// Prepare client data
-	operations := g.extractOperations()
+func (g *Generator) generateClient(operations []Operation) error {
+	var groups []OperationGroup
+	if g.config.GroupByTag {
+		operations, groups = g.groupOperations(operations)
+	}
+	imports := g.clientFileImports(operations)
+	if len(groups) > 0 && len(operations) == 0 {
+		// No operation is left on the client to take a context
+		imports = slices.DeleteFunc(imports, func(imp string) bool { return imp == "context" })
+	}
 
+	// Prepare client data
 	data := map[string]interface{}{
-		"Package":      g.config.ClientPackage,
-		"ClientName":   g.config.ClientName,
-		"Operations":   operations,
-		"Imports":      g.getClientImports(operations),
-		"ModelPackage": g.config.ModelPackage,
-		"BaseURL":      g.getBaseURL(),
+		"Package":         g.config.ClientPackage,
+		"ClientName":      g.config.ClientName,
+		"Operations":      operations,
+		"Imports":         imports,
+		"ModelPackage":    g.config.ModelPackage,
+		"Servers":         g.extractServers(),
+		"SecuritySchemes": g.extractSecuritySchemes(operations),
+		"Groups":          groups,
 	}
 
 	// Generate client file
 	outputPath := filepath.Join(g.config.OutputDir, fmt.Sprintf("%s.go", toSnakeCase(g.config.ClientName)))
 
-	return g.generateFile("client", data, outputPath)
+	if err := g.generateFile("client", data, outputPath); err != nil {
+		return err
+	}
+
+	// Generate a file per sub-client
+	for _, group := range groups {
+		data := map[string]interface{}{
+			"Package":    g.config.ClientPackage,
+			"ClientName": group.TypeName,
+			"Group":      group,
+			"Operations": group.Operations,
+			"Imports":    g.clientFileImports(group.Operations),
+		}
+		if err := g.generateFile("group", data, filepath.Join(g.config.OutputDir, group.FileName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clientFileImports returns the imports of a file holding operations, which
+// only uses fmt in the typed accessors of response wrappers
+func (g *Generator) clientFileImports(operations []Operation) []string {
+	needsFmt := false
+	for _, op := range operations {
+		if !op.HasMultipleSuccessResponses || op.Variant != "" {
+			continue
+		}
+		for code, resp := range op.Responses {
+			if strings.HasPrefix(code, "2") && resp.Type != "" {
+				needsFmt = true
+			}
+		}
+	}
+
+	var imports []string
+	for _, imp := range g.getClientImports(operations) {
+		if imp != "fmt" || needsFmt {
+			imports = append(imports, imp)
+		}
+	}
+	return imports
 }
 
 // generateFile generates a single file from a template
@@ -257,7 +355,32 @@ type Model struct {
 	Description string
 	Fields      []Field
 	IsEnum      bool
-	EnumValues  []string
+	// EnumType is the Go type underlying an enum: string, an integer or a float type
+	EnumType   string
+	EnumValues []EnumValue
+	// IsUnion is set for oneOf/anyOf schemas, generated as a type holding one of Variants
+	IsUnion  bool
+	Variants []UnionVariant
+	// Discriminator is the JSON property selecting the variant, if declared
+	Discriminator string
+	// Validation holds the body of the Validate method of struct models
+	Validation string
+	// XMLName is the XML root element of struct models, set when the spec
+	// declares XML content
+	XMLName string
+}
+
+// UnionVariant represents one of the schemas a union model can hold
+type UnionVariant struct {
+	// Name is used for the As<Name>/From<Name> accessors
+	Name string
+	Type string
+	// DiscriminatorValues are the discriminator values selecting this variant
+	DiscriminatorValues []string
+	// Required holds the JSON names of the properties object variants
+	// require, which data must hold to decode as the variant without a
+	// discriminator
+	Required []string
 }
 
 // Field represents a field in a model
@@ -269,6 +392,11 @@ type Field struct {
 	Required    bool
 	Nullable    bool
 	OmitEmpty   bool
+	// XMLTag is the xml struct tag without omitempty, set when the spec
+	// declares XML content
+	XMLTag string
+	// schema is the property schema, used to generate validation
+	schema *openapi3.Schema
 }
 
 // Operation represents an API operation
@@ -285,6 +413,21 @@ type Operation struct {
 	SuccessResponse             *Response
 	HasMultipleSuccessResponses bool
 	ErrorResponses              []Response
+	Server                      *Server // server declared by the operation or its path, if any
+	// Security lists the scheme names of each security requirement, nil
+	// when the spec declares no security schemes
+	Security [][]string
+	// Variant suffixes the method names of an operation sending another
+	// request body media type or accepting another response media type,
+	// which is VariantMediaType
+	Variant          string
+	VariantMediaType string
+	// MethodName is the Go name of the operation's methods, which is Name
+	// unless its sub-client drops the name of its group
+	MethodName string
+	// Tags are the tags of the operation, the first of which groups it on
+	// a sub-client
+	Tags []string
 }
 
 // Parameter represents an API parameter
@@ -294,6 +437,10 @@ type Parameter struct {
 	Type        string
 	Description string
 	Required    bool
+	Style       string // serialization style, e.g. form, deepObject or matrix
+	Explode     bool
+	VarName     string // Go argument name
+	FieldName   string // field name in the <Operation>Params struct
 }
 
 // RequestBody represents a request body
@@ -301,6 +448,9 @@ type RequestBody struct {
 	Type        string
 	Description string
 	Required    bool
+	MediaType   string
+	// Encodings describe how multipart properties are sent
+	Encodings []PartEncoding
 }
 
 // Response represents an API response
@@ -308,9 +458,22 @@ type Response struct {
 	StatusCode  string
 	Type        string
 	Description string
+	// MediaType is the declared media type the response is decoded from.
+	// Content without a codec is returned as a string for text types and as
+	// a stream otherwise.
+	MediaType string
+	// MediaTypes lists the media types accepted for the response, MediaType first
+	MediaTypes []string
+	// EventType is the type the data of the response's server-sent events
+	// is decoded into, when it declares text/event-stream
+	EventType string
+	// RecordType is the type the records of the response are decoded into,
+	// when it declares newline-delimited JSON
+	RecordType string
 }
 
-// extractModels extracts model definitions from the OpenAPI spec
+// extractModels extracts model definitions from the OpenAPI spec, followed by
+// any models synthesized from inline schemas
 func (g *Generator) extractModels() []Model {
 	var models []Model
 
@@ -318,7 +481,12 @@ func (g *Generator) extractModels() []Model {
 		return models
 	}
 
-	for name, schemaRef := range g.spec.Components.Schemas {
+	g.reserveComponentNames()
+	g.markMultipartSchemas()
+	g.xmlTags = g.declaresXML()
+
+	for _, name := range sortedSchemaNames(g.spec.Components.Schemas) {
+		schemaRef := g.spec.Components.Schemas[name]
 		if schemaRef.Value == nil {
 			continue
 		}
@@ -329,7 +497,7 @@ func (g *Generator) extractModels() []Model {
 		}
 	}
 
-	return models
+	return append(models, g.takeInlineModels()...)
 }
 
 // schemaToModel converts an OpenAPI schema to a Model
@@ -341,42 +509,115 @@ func (g *Generator) schemaToModel(name string, schema *openapi3.Schema) *Model {
 
 	// Handle enums
 	if len(schema.Enum) > 0 {
-		model.IsEnum = true
-		for _, v := range schema.Enum {
-			if str, ok := v.(string); ok {
-				model.EnumValues = append(model.EnumValues, str)
-			}
-		}
+		g.fillEnumModel(model, schema)
 		return model
 	}
 
-	// Handle object types
-	if schema.Type != nil && schema.Type.Is("object") {
-		required := make(map[string]bool)
-		for _, r := range schema.Required {
-			required[r] = true
+	// Handle oneOf/anyOf unions
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		g.fillUnionModel(model, schema)
+		return model
+	}
+
+	// Handle object types, including objects composed with allOf
+	if isObjectSchema(schema) {
+		model.Fields = g.objectFields(model.Name, schema)
+		if g.xmlTags {
+			model.XMLName = xmlRootName(name, schema)
 		}
+	}
 
-		for propName, propRef := range schema.Properties {
-			if propRef.Value == nil {
-				continue
-			}
+	return model
+}
 
-			field := Field{
-				Name:        toPascalCase(propName),
-				JSONName:    propName,
-				Type:        g.schemaRefToGoTypeWithName(propRef, propName),
-				Description: propRef.Value.Description,
-				Required:    required[propName],
-				Nullable:    propRef.Value.Nullable,
-				OmitEmpty:   !required[propName],
-			}
+// objectFields builds the fields of an object schema. Members of allOf are
+// flattened in order, followed by the schema's own properties.
+func (g *Generator) objectFields(modelName string, schema *openapi3.Schema) []Field {
+	var fields []Field
+	index := make(map[string]int)
+
+	collected, required := g.collectObjectFields(modelName, schema, g.multipartSchemas[schema])
+	for _, field := range collected {
+		i, seen := index[field.JSONName]
+		if !seen {
+			index[field.JSONName] = len(fields)
+			fields = append(fields, field)
+			continue
+		}
+		fields[i] = g.mergeFields(modelName, fields[i], field)
+	}
 
-			model.Fields = append(model.Fields, field)
+	// A member may list a property as required without redefining it
+	for i := range fields {
+		if required[fields[i].JSONName] {
+			fields[i].Required = true
+			fields[i].OmitEmpty = false
 		}
 	}
 
-	return model
+	return fields
+}
+
+// collectObjectFields returns the fields contributed by every allOf member and
+// by the schema itself, possibly containing duplicates, along with the names
+// required by any of them. The scope names models synthesized for inline
+// properties; referenced members use their own name as scope instead. Binary
+// properties of multipart bodies become client.File fields.
+func (g *Generator) collectObjectFields(scope string, schema *openapi3.Schema, multipart bool) ([]Field, map[string]bool) {
+	var fields []Field
+	required := make(map[string]bool)
+
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		memberScope := scope
+		if member.Ref != "" {
+			memberScope = g.schemaRefToGoType(member)
+		}
+		memberFields, memberRequired := g.collectObjectFields(memberScope, member.Value, multipart)
+		fields = append(fields, memberFields...)
+		for name := range memberRequired {
+			required[name] = true
+		}
+	}
+
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+
+	for _, propName := range sortedPropertyNames(schema) {
+		propRef := schema.Properties[propName]
+		if propRef.Value == nil {
+			continue
+		}
+
+		goType := ""
+		if multipart {
+			goType = fileFieldType(propRef.Value)
+		}
+		if goType == "" {
+			goType = g.schemaRefToGoTypeInScope(propRef, propName, scope+toPascalCase(propName))
+		}
+
+		field := Field{
+			Name:        toPascalCase(propName),
+			JSONName:    propName,
+			Type:        goType,
+			Description: propRef.Value.Description,
+			Required:    required[propName],
+			Nullable:    propRef.Value.Nullable,
+			OmitEmpty:   !required[propName],
+			schema:      propRef.Value,
+		}
+		if g.xmlTags {
+			field.XMLTag = xmlTag(propName, propRef.Value)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, required
 }
 
 // schemaToGoType converts an OpenAPI schema to a Go type
@@ -393,6 +634,11 @@ func (g *Generator) schemaToGoType(schema *openapi3.Schema) string {
 		}
 	}
 
+	// Handle allOf wrapping a single schema
+	if member := singleAllOfMember(schema); member != nil {
+		return g.schemaRefToGoType(member)
+	}
+
 	// Handle arrays
 	if schema.Type != nil && schema.Type.Is("array") {
 		if schema.Items != nil {
@@ -452,54 +698,118 @@ func (g *Generator) schemaToGoType(schema *openapi3.Schema) string {
 // extractOperations extracts operations from the OpenAPI spec
 func (g *Generator) extractOperations() []Operation {
 	var operations []Operation
+
+	if g.spec.Paths == nil {
+		return operations
+	}
+
+	// Names must be final before extraction, as they scope inline models
+	g.reserveComponentNames()
+	g.markMultipartSchemas()
+	g.xmlTags = g.declaresXML()
+	names := g.operationNames()
+
+	for _, path := range sortedPaths(g.spec.Paths) {
+		pathItem := g.spec.Paths.Value(path)
+		if pathItem != nil {
+			operations = append(operations, g.extractPathOperations(path, pathItem, names)...)
+		}
+	}
+	assignVariantNames(operations)
+
+	return operations
+}
+
+// methodOperation pairs an operation with its HTTP method
+type methodOperation struct {
+	Method    string
+	Operation *openapi3.Operation
+}
+
+// pathItemOperations returns the operations of a path item in a fixed method order
+func pathItemOperations(pathItem *openapi3.PathItem) []methodOperation {
+	var ops []methodOperation
+	for _, mo := range []methodOperation{
+		{"GET", pathItem.Get},
+		{"POST", pathItem.Post},
+		{"PUT", pathItem.Put},
+		{"DELETE", pathItem.Delete},
+		{"PATCH", pathItem.Patch},
+		{"HEAD", pathItem.Head},
+		{"OPTIONS", pathItem.Options},
+	} {
+		if mo.Operation != nil {
+			ops = append(ops, mo)
+		}
+	}
+	return ops
+}
+
+// operationNames assigns a unique Go name to every operation. Names taken
+// from operationIds are kept as-is, while names generated from the method and
+// path get a numeric suffix when they collide.
+func (g *Generator) operationNames() map[*openapi3.Operation]string {
+	names := make(map[*openapi3.Operation]string)
 	usedNames := make(map[string]bool) // Track all used operation names
+	paths := sortedPaths(g.spec.Paths)
 
-	if g.spec.Paths != nil {
-		// First pass: collect all names from operationIds
-		for _, path := range g.spec.Paths.InMatchingOrder() {
-			pathItem := g.spec.Paths.Value(path)
-			if pathItem != nil {
-				ops := g.extractPathOperations(path, pathItem)
-				for _, op := range ops {
-					if op.OperationID != "" {
-						// Mark names from explicit operationIds as used
-						usedNames[op.Name] = true
-					}
-				}
+	// First pass: collect all names from operationIds
+	for _, path := range paths {
+		pathItem := g.spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, mo := range pathItemOperations(pathItem) {
+			if mo.Operation.OperationID != "" {
+				name := toPascalCase(mo.Operation.OperationID)
+				names[mo.Operation] = name
+				usedNames[name] = true
 			}
 		}
+	}
 
-		// Second pass: process all operations and ensure uniqueness
-		for _, path := range g.spec.Paths.InMatchingOrder() {
-			pathItem := g.spec.Paths.Value(path)
-			if pathItem != nil {
-				pathOps := g.extractPathOperations(path, pathItem)
+	// Second pass: name the remaining operations and ensure uniqueness
+	for _, path := range paths {
+		pathItem := g.spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, mo := range pathItemOperations(pathItem) {
+			if mo.Operation.OperationID != "" {
+				continue
+			}
 
-				// Ensure unique operation names
-				for i := range pathOps {
-					if pathOps[i].OperationID == "" {
-						// Only modify names for operations without explicit operationId
-						baseName := pathOps[i].Name
-						finalName := baseName
-						counter := 2
+			baseName := generateOperationName(mo.Method, path)
+			finalName := baseName
+			counter := 2
 
-						// Keep incrementing until we find an unused name
-						for usedNames[finalName] {
-							finalName = fmt.Sprintf("%s%d", baseName, counter)
-							counter++
-						}
+			// Keep incrementing until we find an unused name
+			for usedNames[finalName] {
+				finalName = fmt.Sprintf("%s%d", baseName, counter)
+				counter++
+			}
 
-						pathOps[i].Name = finalName
-						usedNames[finalName] = true
-					}
-				}
+			names[mo.Operation] = finalName
+			usedNames[finalName] = true
+		}
+	}
 
-				operations = append(operations, pathOps...)
+	// Reserve the type names generated alongside each operation so that
+	// inline models never collide with them
+	for _, path := range paths {
+		pathItem := g.spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, mo := range pathItemOperations(pathItem) {
+			name := names[mo.Operation]
+			for _, suffix := range []string{"Params", "ResponseWrapper", "Error"} {
+				g.reserveTypeName(name + suffix)
 			}
 		}
 	}
 
-	return operations
+	return names
 }
 
 // schemaRefToGoType converts an OpenAPI schema reference to a Go type
@@ -528,6 +838,12 @@ func (g *Generator) schemaRefToGoType(schemaRef *openapi3.SchemaRef) string {
 
 // schemaRefToGoTypeWithName converts an OpenAPI schema reference to a Go type with field name context
 func (g *Generator) schemaRefToGoTypeWithName(schemaRef *openapi3.SchemaRef, fieldName string) string {
+	return g.schemaRefToGoTypeInScope(schemaRef, fieldName, toPascalCase(fieldName))
+}
+
+// schemaRefToGoTypeInScope converts an OpenAPI schema reference to a Go type.
+// The scope is the type name used for models synthesized from inline schemas.
+func (g *Generator) schemaRefToGoTypeInScope(schemaRef *openapi3.SchemaRef, fieldName, scope string) string {
 	if schemaRef == nil {
 		return "interface{}"
 	}
@@ -544,7 +860,7 @@ func (g *Generator) schemaRefToGoTypeWithName(schemaRef *openapi3.SchemaRef, fie
 
 	// Otherwise, process the schema value
 	if schemaRef.Value != nil {
-		return g.schemaToGoTypeWithName(schemaRef.Value, fieldName)
+		return g.schemaToGoTypeInScope(schemaRef.Value, fieldName, scope)
 	}
 
 	return "interface{}"
@@ -552,36 +868,57 @@ func (g *Generator) schemaRefToGoTypeWithName(schemaRef *openapi3.SchemaRef, fie
 
 // schemaToGoTypeWithName converts an OpenAPI schema to a Go type with field name context
 func (g *Generator) schemaToGoTypeWithName(schema *openapi3.Schema, fieldName string) string {
+	return g.schemaToGoTypeInScope(schema, fieldName, toPascalCase(fieldName))
+}
+
+// schemaToGoTypeInScope converts an OpenAPI schema to a Go type with field name and scope context
+func (g *Generator) schemaToGoTypeInScope(schema *openapi3.Schema, fieldName, scope string) string {
 	if schema == nil {
 		return "interface{}"
 	}
 
 	// Handle nullable types
 	if schema.Nullable {
-		baseType := g.schemaToGoTypeWithNameNonNullable(schema, fieldName)
+		baseType := g.schemaToGoTypeNonNullableInScope(schema, fieldName, scope)
 		if baseType != "interface{}" && !strings.HasPrefix(baseType, "[]") && !strings.HasPrefix(baseType, "map[") {
 			return "*" + baseType
 		}
 		return baseType
 	}
 
-	return g.schemaToGoTypeWithNameNonNullable(schema, fieldName)
+	return g.schemaToGoTypeNonNullableInScope(schema, fieldName, scope)
 }
 
-// schemaToGoTypeWithNameNonNullable converts a non-nullable OpenAPI schema to a Go type with field name context
-func (g *Generator) schemaToGoTypeWithNameNonNullable(schema *openapi3.Schema, fieldName string) string {
-	// Handle AllOf/AnyOf/OneOf
+// schemaToGoTypeNonNullableInScope converts a non-nullable OpenAPI schema to a Go type with field name and scope context
+func (g *Generator) schemaToGoTypeNonNullableInScope(schema *openapi3.Schema, fieldName, scope string) string {
+	// Handle AllOf: a single structural member is used as-is, anything else
+	// becomes a model merging all members
 	if len(schema.AllOf) > 0 {
-		// For AllOf, we'll use the first schema as the base type
-		if len(schema.AllOf) > 0 && schema.AllOf[0].Value != nil {
-			return g.schemaToGoTypeWithName(schema.AllOf[0].Value, fieldName)
+		if member := singleAllOfMember(schema); member != nil {
+			return g.schemaRefToGoTypeInScope(member, fieldName, scope)
 		}
+		return g.inlineModel(scope, schema)
+	}
+
+	// Handle oneOf/anyOf, which become union models
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return g.inlineModel(scope, schema)
+	}
+
+	// Handle inline enums, which become named enum models
+	if len(schema.Enum) > 0 {
+		return g.inlineModel(scope, schema)
+	}
+
+	// Handle untyped schemas that declare properties
+	if len(schema.Properties) > 0 && isObjectSchema(schema) {
+		return g.inlineModel(scope, schema)
 	}
 
 	// Handle arrays
 	if schema.Type != nil && schema.Type.Is("array") {
 		if schema.Items != nil {
-			return "[]" + g.schemaRefToGoTypeWithName(schema.Items, fieldName)
+			return "[]" + g.schemaRefToGoTypeInScope(schema.Items, fieldName, scope+"Item")
 		}
 		return "[]interface{}"
 	}
@@ -621,13 +958,13 @@ func (g *Generator) schemaToGoTypeWithNameNonNullable(schema *openapi3.Schema, f
 		case "boolean":
 			return "bool"
 		case "object":
+			// Inline objects with properties become named models
+			if len(schema.Properties) > 0 {
+				return g.inlineModel(scope, schema)
+			}
 			// Check if it has additionalProperties defined
 			if schema.AdditionalProperties.Schema != nil {
-				if schema.AdditionalProperties.Schema.Value != nil {
-					return "map[string]" + g.schemaToGoTypeWithName(schema.AdditionalProperties.Schema.Value, fieldName)
-				}
-				// Handle case where additionalProperties is a reference
-				return "map[string]" + g.schemaRefToGoTypeWithName(schema.AdditionalProperties.Schema, fieldName)
+				return "map[string]" + g.schemaRefToGoTypeInScope(schema.AdditionalProperties.Schema, fieldName, scope+"Value")
 			}
 			// If Has is explicitly set to true, it's a map[string]interface{}
 			if schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
@@ -640,8 +977,8 @@ func (g *Generator) schemaToGoTypeWithNameNonNullable(schema *openapi3.Schema, f
 	return "interface{}"
 }
 
-// extractPathOperations extracts operations from a path item
-func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathItem) []Operation {
+// extractPathOperations extracts operations from a path item, named after the given operation names
+func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathItem, names map[*openapi3.Operation]string) []Operation {
 	var operations []Operation
 
 	// Helper function to process an operation
@@ -651,25 +988,27 @@ func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathIt
 		}
 
 		operation := Operation{
+			Name:        names[op],
+			MethodName:  names[op],
+			Tags:        op.Tags,
 			Method:      method,
 			Path:        path,
 			Summary:     op.Summary,
 			Description: op.Description,
 			OperationID: op.OperationID,
 			Responses:   make(map[string]Response),
+			Security:    g.operationSecurity(op),
 		}
 
-		// Generate operation name
-		if op.OperationID != "" {
-			operation.Name = toPascalCase(op.OperationID)
-		} else {
-			// Generate name from method and path when operationId is missing
-			// Uniqueness is ensured in extractOperations()
-			operation.Name = generateOperationName(method, path)
+		// Route to the operation's own server, falling back to the path's
+		if op.Servers != nil && len(*op.Servers) > 0 {
+			operation.Server = operationServer((*op.Servers)[0])
+		} else if len(pathItem.Servers) > 0 {
+			operation.Server = operationServer(pathItem.Servers[0])
 		}
 
-		// Extract parameters
-		for _, paramRef := range op.Parameters {
+		// Extract parameters, including those declared on the path
+		for _, paramRef := range mergeParameters(pathItem.Parameters, op.Parameters) {
 			if paramRef.Value == nil {
 				continue
 			}
@@ -679,28 +1018,39 @@ func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathIt
 				Description: paramRef.Value.Description,
 				Required:    paramRef.Value.Required,
 			}
+			if sm, err := paramRef.Value.SerializationMethod(); err == nil {
+				param.Style, param.Explode = sm.Style, sm.Explode
+			}
 			if paramRef.Value.Schema != nil {
-				param.Type = g.schemaRefToGoType(paramRef.Value.Schema)
+				param.Type = g.schemaRefToGoTypeInScope(paramRef.Value.Schema, "", operation.Name+toPascalCase(param.Name))
 			}
 			operation.Parameters = append(operation.Parameters, param)
 		}
+		assignParamNames(operation.Parameters)
 
-		// Extract request body
+		// Extract request bodies, the preferred one for the operation itself
+		var bodyVariants []*RequestBody
 		if op.RequestBody != nil && op.RequestBody.Value != nil {
-			rb := op.RequestBody.Value
-			if content, ok := rb.Content["application/json"]; ok && content.Schema != nil {
-				operation.RequestBody = &RequestBody{
-					Type:        g.schemaRefToGoType(content.Schema),
-					Description: rb.Description,
-					Required:    rb.Required,
-				}
+			if bodies := g.requestBodies(operation.Name, op.RequestBody.Value); len(bodies) > 0 {
+				operation.RequestBody = bodies[0]
+				bodyVariants = bodies[1:]
 			}
 		}
 
 		// Extract responses
+		var rawVariants []string
 		if op.Responses != nil {
 			successCount := 0
-			for statusCode, responseRef := range op.Responses.Map() {
+			// Visit status codes in sorted order so the lowest 2xx code
+			// consistently becomes the primary success response
+			responseMap := op.Responses.Map()
+			statusCodes := make([]string, 0, len(responseMap))
+			for statusCode := range responseMap {
+				statusCodes = append(statusCodes, statusCode)
+			}
+			sort.Strings(statusCodes)
+			for _, statusCode := range statusCodes {
+				responseRef := responseMap[statusCode]
 				if responseRef.Value == nil {
 					continue
 				}
@@ -712,11 +1062,32 @@ func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathIt
 					StatusCode:  statusCode,
 					Description: desc,
 				}
-				if content, ok := responseRef.Value.Content["application/json"]; ok && content.Schema != nil {
-					resp.Type = g.schemaRefToGoType(content.Schema)
+				// Inline schemas of the primary success response are named <Operation>Response,
+				// others <Operation><Status>Response
+				scope := operation.Name + toPascalCase(statusCode) + "Response"
+				if strings.HasPrefix(statusCode, "2") && operation.SuccessResponse == nil {
+					scope = operation.Name + "Response"
+				}
+				codecTypes, rawTypes := splitMediaTypes(responseRef.Value.Content)
+				if mediaType, content := codecContent(responseRef.Value.Content); content != nil {
+					resp.Type = g.schemaRefToGoTypeInScope(content.Schema, "", scope)
+					resp.MediaType = mediaType
+					resp.MediaTypes = codecTypes
 				} else if content, ok := responseRef.Value.Content["*/*"]; ok && content.Schema != nil {
 					// Handle wildcard content type
-					resp.Type = g.schemaRefToGoType(content.Schema)
+					resp.Type = g.schemaRefToGoTypeInScope(content.Schema, "", scope)
+				} else if len(codecTypes) == 0 && !ok && len(rawTypes) > 0 {
+					// Content without a codec is returned as a string or stream
+					resp.MediaType = rawTypes[0]
+					resp.MediaTypes = rawTypes
+				}
+				for _, mediaType := range rawTypes {
+					if isEventStreamMediaType(mediaType) {
+						resp.EventType = g.eventType(responseRef.Value.Content[mediaType], strings.TrimSuffix(scope, "Response")+"Event")
+					}
+					if isNDJSONMediaType(mediaType) && resp.RecordType == "" {
+						resp.RecordType = g.recordType(responseRef.Value.Content[mediaType], strings.TrimSuffix(scope, "Response")+"Record")
+					}
 				}
 				operation.Responses[statusCode] = resp
 
@@ -725,6 +1096,9 @@ func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathIt
 					successCount++
 					if operation.SuccessResponse == nil {
 						operation.SuccessResponse = &resp
+						if resp.Type != "" {
+							rawVariants = rawTypes
+						}
 					}
 				}
 
@@ -737,16 +1111,13 @@ func (g *Generator) extractPathOperations(path string, pathItem *openapi3.PathIt
 		}
 
 		operations = append(operations, operation)
+		operations = append(operations, mediaTypeVariants(operation, bodyVariants, rawVariants)...)
 	}
 
 	// Process all HTTP methods
-	processOp("GET", pathItem.Get)
-	processOp("POST", pathItem.Post)
-	processOp("PUT", pathItem.Put)
-	processOp("DELETE", pathItem.Delete)
-	processOp("PATCH", pathItem.Patch)
-	processOp("HEAD", pathItem.Head)
-	processOp("OPTIONS", pathItem.Options)
+	for _, mo := range pathItemOperations(pathItem) {
+		processOp(mo.Method, mo.Operation)
+	}
 
 	return operations
 }
@@ -760,6 +1131,40 @@ func (g *Generator) getModelImports(models []Model) []string {
 			if strings.Contains(field.Type, "time.Time") {
 				imports["time"] = true
 			}
+			if strings.Contains(field.Type, "client.File") {
+				imports[g.clientImportPath()] = true
+			}
+		}
+		if model.XMLName != "" {
+			imports["encoding/xml"] = true
+		}
+		if model.IsEnum {
+			imports["fmt"] = true
+			if model.EnumType != "string" {
+				imports["strconv"] = true
+			}
+		}
+		if model.IsUnion {
+			imports["encoding/json"] = true
+			imports["fmt"] = true
+			for _, variant := range model.Variants {
+				if strings.Contains(variant.Type, "time.Time") {
+					imports["time"] = true
+				}
+			}
+		}
+	}
+	if needsDiscriminatorHelper(models) {
+		imports["slices"] = true
+	}
+	if g.config.GenerateValidation {
+		for _, model := range models {
+			if model.IsEnum || model.IsUnion || model.Validation != "" {
+				imports[g.clientImportPath()] = true
+			}
+		}
+		for _, imp := range validationImports(models) {
+			imports[imp] = true
 		}
 	}
 
@@ -767,21 +1172,25 @@ func (g *Generator) getModelImports(models []Model) []string {
 	for imp := range imports {
 		result = append(result, imp)
 	}
+	sort.Strings(result)
 	return result
 }
 
-// getClientImports returns required imports for client
-func (g *Generator) getClientImports(operations []Operation) []string {
-	// Use custom client import path if specified, otherwise use default
-	clientImportPath := g.config.ClientImport
-	if clientImportPath == "" {
-		clientImportPath = "github.com/jmcarbo/oapix/pkg/client"
+// clientImportPath returns the import path of the client package, which is
+// the custom path if specified and the library's otherwise
+func (g *Generator) clientImportPath() string {
+	if g.config.ClientImport != "" {
+		return g.config.ClientImport
 	}
+	return "github.com/jmcarbo/oapix/pkg/client"
+}
 
+// getClientImports returns required imports for client
+func (g *Generator) getClientImports(operations []Operation) []string {
 	imports := map[string]bool{
-		"context":        true,
-		"fmt":            true,
-		clientImportPath: true,
+		"context":            true,
+		"fmt":                true,
+		g.clientImportPath(): true,
 	}
 
 	// Add model import if needed
@@ -789,10 +1198,28 @@ func (g *Generator) getClientImports(operations []Operation) []string {
 		imports[fmt.Sprintf("github.com/jmcarbo/oapix/%s/models", g.config.OutputDir)] = true
 	}
 
-	// Check if any operation uses time.Time or has path parameters
+	// Check if any operation uses time.Time, has path parameters or declares typed errors
 	needsTime := false
 	needsStrings := false
 	for _, op := range operations {
+		if len(typedErrorResponses(op)) > 0 {
+			imports["errors"] = true
+		}
+		if isEventStreamResponse(op) || isRecordStreamResponse(op) {
+			imports["iter"] = true
+		}
+		if isRecordStreamResponse(op) && strings.Contains(op.SuccessResponse.RecordType, "json.RawMessage") {
+			imports["encoding/json"] = true
+		}
+
+		// Check request body streamed as records
+		if op.RequestBody != nil && isNDJSONMediaType(op.RequestBody.MediaType) {
+			imports["iter"] = true
+			if strings.Contains(op.RequestBody.Type, "json.RawMessage") {
+				imports["encoding/json"] = true
+			}
+		}
+
 		// Check if operation has path parameters
 		for _, param := range op.Parameters {
 			if param.In == "path" {
@@ -816,9 +1243,6 @@ func (g *Generator) getClientImports(operations []Operation) []string {
 			}
 		}
 
-		if needsTime && needsStrings {
-			break
-		}
 	}
 
 	if needsTime {
@@ -832,17 +1256,10 @@ func (g *Generator) getClientImports(operations []Operation) []string {
 	for imp := range imports {
 		result = append(result, imp)
 	}
+	sort.Strings(result)
 	return result
 }
 
-// getBaseURL extracts base URL from the spec
-func (g *Generator) getBaseURL() string {
-	if len(g.spec.Servers) > 0 {
-		return g.spec.Servers[0].URL
-	}
-	return "https://api.example.com"
-}
-
 // GenerateFromReader generates code from an OpenAPI spec reader
 func GenerateFromReader(reader io.Reader, config *Config) error {
 	// Create temporary file
@@ -871,9 +871,9 @@ components:
 		"func (c *UserAPIClient) DeleteUser",
 		"ListUsersParams struct",
 		"GetUserParams struct",
-		"Page int64",
-		"Limit int64",
-		"XRequestID string",
+		"Page *int64",
+		"Limit *int64",
+		"XRequestID *string",
 	}
 
 	for _, expected := range expectedClient {
@@ -1198,3 +1198,493 @@ func TestSchemaToGoTypeWithDateFields(t *testing.T) {
 		})
 	}
 }
+
+// generateFromSpec writes specContent to a temporary file, runs the generator
+// with the given config and returns the contents of the generated files keyed
+// by file name
+func generateFromSpec(t *testing.T, specContent string, config *Config) map[string]string {
+	t.Helper()
+
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config.SpecPath = specPath
+	if config.OutputDir == "" {
+		config.OutputDir = t.TempDir()
+	}
+	if config.PackageName == "" {
+		config.PackageName = "testapi"
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.LoadSpec(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	entries, err := os.ReadDir(config.OutputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(config.OutputDir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[entry.Name()] = string(content)
+	}
+	return files
+}
+
+func TestGenerateTypedResponses(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Typed API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        '404':
+          description: Not found
+    put:
+      operationId: upsertUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '200':
+          description: Updated
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+    delete:
+      operationId: deleteUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No content
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"func (c *Client) ListUsers(ctx context.Context) ([]User, error)",
+		"func (c *Client) GetUser(ctx context.Context, id string) (*User, error)",
+		"func (c *Client) UpsertUser(ctx context.Context, id string, req User) (*UpsertUserResponseWrapper, error)",
+		"func (c *Client) DeleteUser(ctx context.Context, id string) error",
+		"func (c *Client) GetUserRaw(ctx context.Context, id string) (*client.MultiResponse, error)",
+		"func (c *Client) DeleteUserRaw(ctx context.Context, id string) (*client.MultiResponse, error)",
+		"return WrapUpsertUserResponse(resp), nil",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateClientImportsFmtWhenUsed(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Plain API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '204':
+          description: No content
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	// Only the accessors of response wrappers use fmt
+	if strings.Contains(clientStr, `"fmt"`) {
+		t.Error("client.go should not import fmt")
+	}
+	if !strings.Contains(clientStr, "baseClient, err := client.NewBaseClient(config)\n\tif err != nil {\n\t\treturn nil, err\n\t}") {
+		t.Error("NewClient should return the error of NewBaseClient")
+	}
+}
+
+func TestGenerateTypedErrors(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Errors API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      responses:
+        '201':
+          description: Created
+        '422':
+          description: Validation failed
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ValidationError'
+        '5XX':
+          description: Server error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ServerError'
+        '403':
+          description: Forbidden
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: Success
+components:
+  schemas:
+    ValidationError:
+      type: object
+      properties:
+        field:
+          type: string
+    ServerError:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"type CreateUserError struct",
+		"Status422 *ValidationError",
+		"Status5XX *ServerError",
+		"func (e *CreateUserError) Unwrap() error",
+		"case apiErr.StatusCode == 422:",
+		"case apiErr.StatusCode >= 500 && apiErr.StatusCode < 600:",
+		"return nil, decodeCreateUserError(err)",
+		`"errors"`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+
+	if strings.Contains(clientStr, "ListUsersError") {
+		t.Error("client.go should not declare an error type for operations without typed errors")
+	}
+	if strings.Contains(clientStr, "Status403") {
+		t.Error("client.go should not declare fields for error responses without a schema")
+	}
+}
+
+func TestGenerateOptionalParameters(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Items API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: listItems
+      parameters:
+        - name: category
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: page
+          in: query
+          schema:
+            type: integer
+        - name: X-Trace-ID
+          in: header
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No content
+  /tags:
+    get:
+      operationId: listTags
+      parameters:
+        - name: prefix
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No content
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"ListItems(ctx context.Context, category string, params *ListItemsParams) error",
+		"ListTags(ctx context.Context, prefix string) error",
+		`opts = append(opts, client.WithQueryValues(client.QueryParam("category", category, client.StyleForm, true)))`,
+		"if params.Page != nil {",
+		`opts = append(opts, client.WithQueryValues(client.QueryParam("page", *params.Page, client.StyleForm, true)))`,
+		"Page *int64",
+		"XTraceID *string",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+
+	if strings.Contains(clientStr, "ListTagsParams") {
+		t.Error("client.go should not declare a params struct for operations without optional parameters")
+	}
+	if strings.Contains(clientStr, "Category *string") {
+		t.Error("required parameters should not be part of the params struct")
+	}
+}
+
+func TestGenerateParameterStyles(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /search/{ids}:
+    get:
+      operationId: search
+      parameters:
+        - name: ids
+          in: path
+          required: true
+          style: matrix
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: status
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: filter
+          in: query
+          style: deepObject
+          explode: true
+          schema:
+            type: object
+            properties:
+              name:
+                type: string
+        - name: X-Tags
+          in: header
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '204':
+          description: No content
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		`strings.ReplaceAll("/search/{ids}", "{ids}", client.PathParam("ids", ids, client.StyleMatrix, true))`,
+		`client.QueryParam("status", params.Status, client.StyleForm, true)`,
+		`client.QueryParam("filter", *params.Filter, client.StyleDeepObject, true)`,
+		`client.WithHeader("X-Tags", client.HeaderParam(params.XTags, false))`,
+		"Filter *SearchFilter",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateCookieParameters(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Legacy API
+  version: 1.0.0
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      parameters:
+        - name: session
+          in: cookie
+          required: true
+          schema:
+            type: string
+        - name: csrf_token
+          in: cookie
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No content
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"CreateOrder(ctx context.Context, session string, params *CreateOrderParams) error",
+		`opts = append(opts, client.WithCookie("session", client.CookieParam(session, true)))`,
+		`opts = append(opts, client.WithCookie("csrf_token", client.CookieParam(*params.CsrfToken, true)))`,
+		"CsrfToken *string",
+		`c.Request(ctx, "POST", path, nil, opts...)`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGeneratePathLevelParametersAndServers(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Files API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /files/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+      - name: X-Tenant
+        in: header
+        schema:
+          type: string
+    servers:
+      - url: https://files.example.com
+    get:
+      operationId: getFile
+      parameters:
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No content
+    delete:
+      operationId: deleteFile
+      servers:
+        - url: https://admin.example.com
+      responses:
+        '204':
+          description: No content
+  /health:
+    get:
+      operationId: health
+      responses:
+        '204':
+          description: No content
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"GetFile(ctx context.Context, id string, xTenant string) error",
+		"DeleteFile(ctx context.Context, id string, params *DeleteFileParams) error",
+		"client.WithServer(client.Server{\n\t\tURL: \"https://files.example.com\",\n\t}))",
+		"client.WithServer(client.Server{\n\t\tURL: \"https://admin.example.com\",\n\t}))",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+
+	if strings.Count(clientStr, "client.WithServer(") != 2 {
+		t.Error("only operations with their own servers should override the base URL")
+	}
+}
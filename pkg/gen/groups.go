@@ -0,0 +1,154 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// OperationGroup is a sub-client holding the operations of a tag, or of a
+// path prefix for untagged operations
+type OperationGroup struct {
+	// Field is the name of the client field holding the sub-client
+	Field    string
+	TypeName string
+	// Tag is the tag of the operations, empty when grouped by PathPrefix
+	Tag         string
+	PathPrefix  string
+	Description string
+	// FileName is the name of the file the sub-client is generated in
+	FileName   string
+	Operations []Operation
+}
+
+// groupOperations splits operations into groups by their first tag, or else
+// the first segment of their path, and those left on the client, which have
+// neither. Method names drop the name of their group when that keeps them
+// apart from the group's other methods.
+func (g *Generator) groupOperations(operations []Operation) ([]Operation, []OperationGroup) {
+	var ungrouped []Operation
+	byField := make(map[string]*OperationGroup)
+	for _, op := range operations {
+		tag, prefix := operationGroupKey(op)
+		field := toPascalCase(tag + strings.TrimPrefix(prefix, "/"))
+		if field == "" || !unicode.IsLetter([]rune(field)[0]) {
+			ungrouped = append(ungrouped, op)
+			continue
+		}
+		group, ok := byField[field]
+		if !ok {
+			group = &OperationGroup{Field: field, Tag: tag, PathPrefix: prefix, Description: g.tagDescription(tag)}
+			byField[field] = group
+		}
+		group.Operations = append(group.Operations, op)
+	}
+
+	fields := make([]string, 0, len(byField))
+	for field := range byField {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	// Fields can't share the name of a method of the client
+	used := map[string]bool{"Client": true}
+	for _, name := range clientMethods {
+		used[name] = true
+	}
+	for _, op := range ungrouped {
+		used[op.MethodName+op.Variant] = true
+		used[op.MethodName+op.Variant+"Raw"] = true
+	}
+	for _, scheme := range g.extractSecuritySchemes(ungrouped) {
+		used[scheme.Setter] = true
+	}
+
+	groups := make([]OperationGroup, 0, len(fields))
+	for _, name := range fields {
+		group := *byField[name]
+		for counter := 2; used[group.Field]; counter++ {
+			group.Field = fmt.Sprintf("%s%d", name, counter)
+		}
+		used[group.Field] = true
+		group.TypeName = g.reserveTypeName(group.Field + "Client")
+		group.FileName = toSnakeCase(group.TypeName) + ".go"
+		trimGroupName(name, group.Operations)
+		groups = append(groups, group)
+	}
+	return ungrouped, groups
+}
+
+// operationGroupKey returns the first tag of an operation, or else the first
+// segment of its path that isn't a parameter, such as /users
+func operationGroupKey(op Operation) (tag, pathPrefix string) {
+	if len(op.Tags) > 0 && op.Tags[0] != "" {
+		return op.Tags[0], ""
+	}
+	for _, segment := range strings.Split(op.Path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			return "", "/" + segment
+		}
+	}
+	return "", ""
+}
+
+// tagDescription returns the description the spec declares for a tag
+func (g *Generator) tagDescription(name string) string {
+	if name == "" {
+		return ""
+	}
+	if tag := g.spec.Tags.Get(name); tag != nil {
+		return tag.Description
+	}
+	return ""
+}
+
+// trimGroupName drops the name of a group, or its singular, from the start
+// of the method names of its operations, as in Users.Get for UsersGet. Names
+// keep the prefix when the rest doesn't start a new word, or when trimming
+// it would clash with another method of the group or of client.Client.
+func trimGroupName(group string, operations []Operation) {
+	used := make(map[string]bool)
+	for _, name := range clientMethods {
+		used[name] = true
+	}
+	variants := make(map[string][]string)
+	var names []string
+	for _, op := range operations {
+		if _, ok := variants[op.Name]; !ok {
+			names = append(names, op.Name)
+		}
+		variants[op.Name] = append(variants[op.Name], op.Variant)
+		used[op.Name+op.Variant] = true
+		used[op.Name+op.Variant+"Raw"] = true
+	}
+
+	methodNames := make(map[string]string)
+	for _, name := range names {
+		for _, prefix := range []string{group, singularize(group)} {
+			rest, ok := strings.CutPrefix(name, prefix)
+			if !ok || rest == "" || !unicode.IsUpper([]rune(rest)[0]) {
+				continue
+			}
+			clashes := false
+			for _, variant := range variants[name] {
+				clashes = clashes || used[rest+variant] || used[rest+variant+"Raw"]
+			}
+			if clashes {
+				continue
+			}
+			for _, variant := range variants[name] {
+				used[rest+variant] = true
+				used[rest+variant+"Raw"] = true
+			}
+			methodNames[name] = rest
+			break
+		}
+	}
+
+	for i := range operations {
+		if methodName, ok := methodNames[operations[i].Name]; ok {
+			operations[i].MethodName = methodName
+		}
+	}
+}
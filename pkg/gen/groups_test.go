@@ -0,0 +1,160 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateGroupByTag(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Accounts API
+  version: 1.0.0
+tags:
+  - name: users
+    description: Manage users
+paths:
+  /users:
+    get:
+      operationId: usersList
+      tags: [users]
+      responses:
+        '204':
+          description: OK
+  /users/{id}:
+    get:
+      operationId: getUser
+      tags: [users]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: OK
+  /invoices:
+    get:
+      operationId: listInvoices
+      responses:
+        '204':
+          description: OK
+  /:
+    get:
+      operationId: root
+      responses:
+        '204':
+          description: OK
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true, GroupByTag: true})
+
+	tests := []struct {
+		file     string
+		expected []string
+	}{
+		{
+			file: "client.go",
+			expected: []string{
+				"Invoices *InvoicesClient",
+				"Users *UsersClient",
+				"Users:    &UsersClient{Client: baseClient},",
+				// Operations without a tag or path prefix stay on the client
+				"func (c *Client) Root(ctx context.Context) error {",
+			},
+		},
+		{
+			file: "users_client.go",
+			expected: []string{
+				"// UsersClient holds the operations tagged users\n//\n// Manage users\ntype UsersClient struct {",
+				"func (c *UsersClient) List(ctx context.Context) error {",
+				"func (c *UsersClient) GetUser(ctx context.Context, id string) error {",
+			},
+		},
+		{
+			file: "invoices_client.go",
+			expected: []string{
+				"// InvoicesClient holds the operations under /invoices",
+				"func (c *InvoicesClient) ListInvoices(ctx context.Context) error {",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		content, ok := files[tt.file]
+		if !ok {
+			t.Errorf("%s was not generated", tt.file)
+			continue
+		}
+		for _, exp := range tt.expected {
+			if !strings.Contains(content, exp) {
+				t.Errorf("%s should contain %q", tt.file, exp)
+			}
+		}
+	}
+	if strings.Contains(files["client.go"], "func (c *Client) ListInvoices") {
+		t.Error("grouped operations should not be generated on the client")
+	}
+}
+
+func TestTrimGroupName(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+		ops   []Operation
+		want  []string
+	}{
+		{
+			name:  "group prefix",
+			group: "Users",
+			ops:   []Operation{{Name: "UsersList"}, {Name: "UserGet"}, {Name: "ListUsers"}},
+			want:  []string{"List", "Get", "ListUsers"},
+		},
+		{
+			name:  "prefix of a word",
+			group: "User",
+			ops:   []Operation{{Name: "Usernames"}, {Name: "User"}},
+			want:  []string{"Usernames", "User"},
+		},
+		{
+			name:  "clash with another method",
+			group: "Users",
+			ops:   []Operation{{Name: "UsersList"}, {Name: "List"}},
+			want:  []string{"UsersList", "List"},
+		},
+		{
+			name:  "clash with a raw method",
+			group: "Users",
+			ops:   []Operation{{Name: "UsersGet"}, {Name: "GetRaw"}},
+			want:  []string{"UsersGet", "GetRaw"},
+		},
+		{
+			name:  "clash with client methods",
+			group: "Users",
+			ops:   []Operation{{Name: "UsersRequest"}},
+			want:  []string{"UsersRequest"},
+		},
+		{
+			name:  "variants",
+			group: "Users",
+			ops:   []Operation{{Name: "UsersCreate"}, {Name: "UsersCreate", Variant: "Form"}},
+			want:  []string{"Create", "Create"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.ops {
+				tt.ops[i].MethodName = tt.ops[i].Name
+			}
+			trimGroupName(tt.group, tt.ops)
+			for i, op := range tt.ops {
+				if op.MethodName != tt.want[i] {
+					t.Errorf("MethodName of %s = %q, want %q", op.Name, op.MethodName, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package gen
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 	"unicode"
@@ -38,8 +39,37 @@ func templateFuncs() template.FuncMap {
 		"hasPathParams":            hasPathParams,
 		"hasQueryParams":           hasQueryParams,
 		"hasHeaderParams":          hasHeaderParams,
+		"hasCookieParams":          hasCookieParams,
+		"requestBodyMethod":        requestBodyMethod,
+		"partEncodingOption":       partEncodingOption,
+		"setupRequest":             setupRequest,
+		"hasRequestOptions":        hasRequestOptions,
+		"acceptOption":             acceptOption,
+		"variantDoc":               variantDoc,
+		"requestContentType":       requestContentType,
+		"isStreamResponse":         isStreamResponse,
+		"isTextResponse":           isTextResponse,
+		"isEventStreamResponse":    isEventStreamResponse,
+		"isRecordStreamResponse":   isRecordStreamResponse,
+		"requestBodyArg":           requestBodyArg,
+		"optionalRequester":        optionalRequester,
+		"serverLiteral":            serverLiteral,
+		"stringSliceLiteral":       stringSliceLiteral,
+		"securitySchemeLiteral":    securitySchemeLiteral,
+		"securityOption":           securityOption,
 		"filterParamsByIn":         filterParamsByIn,
 		"buildMethodSignature":     buildMethodSignature,
+		"buildCallArguments":       buildCallArguments,
+		"successResultType":        successResultType,
+		"isPointerResult":          isPointerResult,
+		"enumParseCall":            enumParseCall,
+		"requiredParams":           requiredParams,
+		"optionalParams":           optionalParams,
+		"optionalParamType":        optionalParamType,
+		"optionalParamValue":       optionalParamValue,
+		"paramOption":              paramOption,
+		"typedErrorResponses":      typedErrorResponses,
+		"statusCodeCondition":      statusCodeCondition,
 		"goDoc":                    goDoc,
 		"inc":                      inc,
 		"dec":                      dec,
@@ -250,8 +280,9 @@ func isBuiltinType(t string) bool {
 
 // needsPointer determines if a field should be a pointer
 func needsPointer(field Field) bool {
-	// Slices, maps, and interfaces don't need pointers for omitempty
-	if strings.HasPrefix(field.Type, "[]") ||
+	// Slices, maps, interfaces and types that are already pointers don't need pointers for omitempty
+	if strings.HasPrefix(field.Type, "*") ||
+		strings.HasPrefix(field.Type, "[]") ||
 		strings.HasPrefix(field.Type, "map[") ||
 		field.Type == "interface{}" {
 		return false
@@ -384,23 +415,9 @@ func buildPathWithNamedParams(path string, params []Parameter) string {
 
 	for _, paramName := range pathParamNames {
 		if param, ok := pathParams[paramName]; ok {
-			// Determine the format verb based on parameter type
-			formatVerb := "%v" // default format
-			switch param.Type {
-			case "int", "int8", "int16", "int32", "int64",
-				"uint", "uint8", "uint16", "uint32", "uint64":
-				formatVerb = "%d"
-			case "float32", "float64":
-				formatVerb = "%f"
-			case "bool":
-				formatVerb = "%t"
-			case "string":
-				formatVerb = "%s"
-			}
-
-			// Build nested string replacement
-			result = fmt.Sprintf(`strings.ReplaceAll(%s, "{%s}", fmt.Sprintf("%s", %s))`,
-				result, paramName, formatVerb, param.Name)
+			// The client serializes and escapes the value according to its style
+			result = fmt.Sprintf(`strings.ReplaceAll(%s, "{%s}", client.PathParam(%q, %s, %s, %t))`,
+				result, paramName, param.Name, param.VarName, paramStyle(param), param.Explode)
 		}
 	}
 
@@ -450,6 +467,16 @@ func hasHeaderParams(params []Parameter) bool {
 	return false
 }
 
+// hasCookieParams checks if operation has cookie parameters
+func hasCookieParams(params []Parameter) bool {
+	for _, p := range params {
+		if p.In == "cookie" {
+			return true
+		}
+	}
+	return false
+}
+
 // filterParamsByIn filters parameters by their location
 func filterParamsByIn(params []Parameter, in string) []Parameter {
 	var filtered []Parameter
@@ -461,6 +488,73 @@ func filterParamsByIn(params []Parameter, in string) []Parameter {
 	return filtered
 }
 
+// requiredParams returns the required query, header and cookie parameters,
+// which generated methods take as arguments
+func requiredParams(params []Parameter) []Parameter {
+	var required []Parameter
+	for _, p := range params {
+		if p.In != "path" && p.Required {
+			required = append(required, p)
+		}
+	}
+	return required
+}
+
+// optionalParams returns the optional query, header and cookie parameters,
+// which generated methods take in their <Operation>Params struct
+func optionalParams(params []Parameter) []Parameter {
+	var optional []Parameter
+	for _, p := range params {
+		if p.In != "path" && !p.Required {
+			optional = append(optional, p)
+		}
+	}
+	return optional
+}
+
+// optionalParamType returns the type of an optional parameter field. Scalars
+// become pointers so that unset parameters can be told apart and left out.
+func optionalParamType(t string) string {
+	if isNillableType(t) {
+		return t
+	}
+	return "*" + t
+}
+
+// optionalParamValue returns the expression reading a set optional parameter
+// from the params struct
+func optionalParamValue(p Parameter) string {
+	field := "params." + p.FieldName
+	if strings.HasPrefix(optionalParamType(p.Type), "*") {
+		return "*" + field
+	}
+	return field
+}
+
+// paramOption returns the request option sending a query, header or cookie
+// parameter with the given value, serialized according to the parameter's style
+func paramOption(p Parameter, value string) string {
+	switch p.In {
+	case "header":
+		return fmt.Sprintf("client.WithHeader(%q, client.HeaderParam(%s, %t))", p.Name, value, p.Explode)
+	case "cookie":
+		return fmt.Sprintf("client.WithCookie(%q, client.CookieParam(%s, %t))", p.Name, value, p.Explode)
+	}
+	return fmt.Sprintf("client.WithQueryValues(client.QueryParam(%q, %s, %s, %t))", p.Name, value, paramStyle(p), p.Explode)
+}
+
+// paramStyle returns the client constant for a parameter's serialization style
+func paramStyle(p Parameter) string {
+	style := p.Style
+	if style == "" {
+		style = "form"
+		if p.In == "path" || p.In == "header" {
+			style = "simple"
+		}
+	}
+	return "client.Style" + strings.ToUpper(style[:1]) + style[1:]
+}
+
 // buildMethodSignature builds a Go method signature for an operation
 func buildMethodSignature(op Operation) string {
 	parts := []string{"ctx context.Context"}
@@ -469,23 +563,116 @@ func buildMethodSignature(op Operation) string {
 	for _, param := range op.Parameters {
 		if param.In == "path" {
 			// Use the parameter name as-is for method signatures
-			parts = append(parts, param.Name+" "+param.Type)
+			parts = append(parts, param.VarName+" "+param.Type)
 		}
 	}
 
+	// Add required query/header parameters
+	for _, param := range requiredParams(op.Parameters) {
+		parts = append(parts, param.VarName+" "+param.Type)
+	}
+
 	// Add request body
 	if op.RequestBody != nil {
 		parts = append(parts, "req "+op.RequestBody.Type)
 	}
 
-	// Add optional parameters struct if there are query/header params
-	if hasQueryParams(op.Parameters) || hasHeaderParams(op.Parameters) {
+	// Add optional parameters struct if there are optional query/header params
+	if len(optionalParams(op.Parameters)) > 0 {
 		parts = append(parts, "params *"+op.Name+"Params")
 	}
 
 	return strings.Join(parts, ", ")
 }
 
+// buildCallArguments builds the argument list used to forward a call made
+// with the signature produced by buildMethodSignature
+func buildCallArguments(op Operation) string {
+	parts := []string{"ctx"}
+
+	for _, param := range op.Parameters {
+		if param.In == "path" {
+			parts = append(parts, param.VarName)
+		}
+	}
+
+	for _, param := range requiredParams(op.Parameters) {
+		parts = append(parts, param.VarName)
+	}
+
+	if op.RequestBody != nil {
+		parts = append(parts, "req")
+	}
+
+	if len(optionalParams(op.Parameters)) > 0 {
+		parts = append(parts, "params")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// successResultType returns the Go type returned by the typed method of an
+// operation, or an empty string when the operation only returns an error
+func successResultType(op Operation) string {
+	if op.HasMultipleSuccessResponses {
+		return "*" + op.Name + "ResponseWrapper"
+	}
+	if isEventStreamResponse(op) {
+		return fmt.Sprintf("iter.Seq2[%s, error]", op.SuccessResponse.EventType)
+	}
+	if isRecordStreamResponse(op) {
+		return fmt.Sprintf("iter.Seq2[%s, error]", op.SuccessResponse.RecordType)
+	}
+	if isStreamResponse(op) {
+		return "*client.StreamResponse"
+	}
+	if isTextResponse(op) {
+		return "string"
+	}
+	if op.SuccessResponse == nil || op.SuccessResponse.Type == "" {
+		return ""
+	}
+	if isPointerResult(op.SuccessResponse.Type) {
+		return "*" + op.SuccessResponse.Type
+	}
+	return op.SuccessResponse.Type
+}
+
+// isPointerResult reports whether a decoded response of the given type
+// should be returned by pointer
+func isPointerResult(t string) bool {
+	return !strings.HasPrefix(t, "*") &&
+		!strings.HasPrefix(t, "[]") &&
+		!strings.HasPrefix(t, "map[") &&
+		t != "interface{}"
+}
+
+// typedErrorResponses returns the declared 4xx/5xx responses of an operation
+// that carry a schema, in status code order
+func typedErrorResponses(op Operation) []Response {
+	var typed []Response
+	for _, resp := range op.ErrorResponses {
+		if resp.Type != "" {
+			typed = append(typed, resp)
+		}
+	}
+	sort.Slice(typed, func(i, j int) bool {
+		return typed[i].StatusCode < typed[j].StatusCode
+	})
+	return typed
+}
+
+// statusCodeCondition builds a Go boolean expression matching an OpenAPI
+// status code, including range codes such as 4XX, against the given variable
+func statusCodeCondition(statusCode, variable string) string {
+	upper := strings.ToUpper(statusCode)
+	if len(upper) == 3 && strings.HasSuffix(upper, "XX") {
+		class := int(upper[0]-'0') * 100
+		return fmt.Sprintf("%s >= %d && %s < %d", variable, class, variable, class+100)
+	}
+	return fmt.Sprintf("%s == %s", variable, statusCode)
+}
+
 // goDoc formats a string as a Go doc comment
 func goDoc(s string, prefix string) string {
 	s = strings.TrimSpace(s)
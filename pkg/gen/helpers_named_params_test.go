@@ -17,7 +17,7 @@ func TestBuildPathWithNamedParams(t *testing.T) {
 			params: []Parameter{
 				{Name: "id", In: "path", Type: "string"},
 			},
-			expected: `strings.ReplaceAll("/users/{id}", "{id}", fmt.Sprintf("%s", id))`,
+			expected: `strings.ReplaceAll("/users/{id}", "{id}", client.PathParam("id", id, client.StyleSimple, false))`,
 		},
 		{
 			name: "multiple parameters in order",
@@ -26,7 +26,7 @@ func TestBuildPathWithNamedParams(t *testing.T) {
 				{Name: "userId", In: "path", Type: "string"},
 				{Name: "postId", In: "path", Type: "string"},
 			},
-			expected: `strings.ReplaceAll(strings.ReplaceAll("/users/{userId}/posts/{postId}", "{userId}", fmt.Sprintf("%s", userId)), "{postId}", fmt.Sprintf("%s", postId))`,
+			expected: `strings.ReplaceAll(strings.ReplaceAll("/users/{userId}/posts/{postId}", "{userId}", client.PathParam("userId", userId, client.StyleSimple, false)), "{postId}", client.PathParam("postId", postId, client.StyleSimple, false))`,
 		},
 		{
 			name: "multiple parameters out of order",
@@ -35,7 +35,7 @@ func TestBuildPathWithNamedParams(t *testing.T) {
 				{Name: "id", In: "path", Type: "string"},
 				{Name: "packageKey", In: "path", Type: "string"},
 			},
-			expected: `strings.ReplaceAll(strings.ReplaceAll("/api/v1/packages/{packageKey}/public-form-links/{id}", "{packageKey}", fmt.Sprintf("%s", packageKey)), "{id}", fmt.Sprintf("%s", id))`,
+			expected: `strings.ReplaceAll(strings.ReplaceAll("/api/v1/packages/{packageKey}/public-form-links/{id}", "{packageKey}", client.PathParam("packageKey", packageKey, client.StyleSimple, false)), "{id}", client.PathParam("id", id, client.StyleSimple, false))`,
 		},
 		{
 			name: "path with query parameters (should ignore)",
@@ -44,7 +44,7 @@ func TestBuildPathWithNamedParams(t *testing.T) {
 				{Name: "userId", In: "path", Type: "string"},
 				{Name: "active", In: "query", Type: "bool"},
 			},
-			expected: `strings.ReplaceAll("/users/{userId}", "{userId}", fmt.Sprintf("%s", userId))`,
+			expected: `strings.ReplaceAll("/users/{userId}", "{userId}", client.PathParam("userId", userId, client.StyleSimple, false))`,
 		},
 		{
 			name: "no path parameters",
@@ -61,12 +61,13 @@ func TestBuildPathWithNamedParams(t *testing.T) {
 				{Name: "item-id", In: "path", Type: "string"},
 				{Name: "sub_item_id", In: "path", Type: "string"},
 			},
-			expected: `strings.ReplaceAll(strings.ReplaceAll("/items/{item-id}/sub-items/{sub_item_id}", "{item-id}", fmt.Sprintf("%s", item-id)), "{sub_item_id}", fmt.Sprintf("%s", sub_item_id))`,
+			expected: `strings.ReplaceAll(strings.ReplaceAll("/items/{item-id}/sub-items/{sub_item_id}", "{item-id}", client.PathParam("item-id", itemID, client.StyleSimple, false)), "{sub_item_id}", client.PathParam("sub_item_id", sub_item_id, client.StyleSimple, false))`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			assignParamNames(tt.params)
 			result := buildPathWithNamedParams(tt.path, tt.params)
 			if result != tt.expected {
 				t.Errorf("buildPathWithNamedParams() = %v, want %v", result, tt.expected)
@@ -95,8 +96,9 @@ func TestBuildPathWithNamedParamsVsBuildPath(t *testing.T) {
 	}
 
 	// New approach generates code that should produce the same result
+	assignParamNames(params)
 	newCode := buildPathWithNamedParams(path, params)
-	expectedNew := `strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll("/users/{userId}/posts/{postId}/comments/{commentId}", "{userId}", fmt.Sprintf("%s", userId)), "{postId}", fmt.Sprintf("%s", postId)), "{commentId}", fmt.Sprintf("%s", commentId))`
+	expectedNew := `strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll("/users/{userId}/posts/{postId}/comments/{commentId}", "{userId}", client.PathParam("userId", userId, client.StyleSimple, false)), "{postId}", client.PathParam("postId", postId, client.StyleSimple, false)), "{commentId}", client.PathParam("commentId", commentId, client.StyleSimple, false))`
 	if newCode != expectedNew {
 		t.Errorf("buildPathWithNamedParams() = %v, want %v", newCode, expectedNew)
 	}
@@ -235,6 +235,15 @@ func TestNeedsPointer(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "nullable type already a pointer",
+			field: Field{
+				Type:     "*Pet",
+				Required: false,
+				Nullable: true,
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -439,10 +448,40 @@ func TestBuildMethodSignature(t *testing.T) {
 			},
 			want: "ctx context.Context, userId int64, postId int64, req UpdatePostRequest, params *UpdateUserPostParams",
 		},
+		{
+			name: "required query and header parameters",
+			op: Operation{
+				Name:   "ListItems",
+				Method: "GET",
+				Parameters: []Parameter{
+					{Name: "category", In: "query", Type: "string", Required: true},
+					{Name: "X-Trace-ID", In: "header", Type: "string", Required: true},
+				},
+				RequestBody: &RequestBody{
+					Type: "ListItemsRequest",
+				},
+			},
+			want: "ctx context.Context, category string, xTraceID string, req ListItemsRequest",
+		},
+		{
+			name: "parameter names that are not Go identifiers",
+			op: Operation{
+				Name:   "GetItem",
+				Method: "GET",
+				Parameters: []Parameter{
+					{Name: "user-id", In: "path", Type: "string"},
+					{Name: "type", In: "path", Type: "string"},
+					{Name: "ctx", In: "query", Type: "string", Required: true},
+					{Name: "user.id", In: "header", Type: "string", Required: true},
+				},
+			},
+			want: "ctx context.Context, userID string, typeParam string, ctxParam string, userID2 string",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			assignParamNames(tt.op.Parameters)
 			got := buildMethodSignature(tt.op)
 			if got != tt.want {
 				t.Errorf("buildMethodSignature() = %q, want %q", got, tt.want)
@@ -451,6 +490,181 @@ func TestBuildMethodSignature(t *testing.T) {
 	}
 }
 
+func TestBuildCallArguments(t *testing.T) {
+	op := Operation{
+		Name: "UpdateUserPost",
+		Parameters: []Parameter{
+			{Name: "userId", In: "path", Type: "int64"},
+			{Name: "postId", In: "path", Type: "int64"},
+			{Name: "X-Request-ID", In: "header", Type: "string"},
+		},
+		RequestBody: &RequestBody{Type: "UpdatePostRequest"},
+	}
+	assignParamNames(op.Parameters)
+
+	want := "ctx, userId, postId, req, params"
+	if got := buildCallArguments(op); got != want {
+		t.Errorf("buildCallArguments() = %q, want %q", got, want)
+	}
+
+	op.Parameters[2].Required = true
+	want = "ctx, userId, postId, xRequestID, req"
+	if got := buildCallArguments(op); got != want {
+		t.Errorf("buildCallArguments() = %q, want %q", got, want)
+	}
+}
+
+func TestOptionalParams(t *testing.T) {
+	params := []Parameter{
+		{Name: "id", In: "path", Type: "string", Required: true},
+		{Name: "category", In: "query", Type: "string", Required: true},
+		{Name: "page", In: "query", Type: "int64"},
+		{Name: "tags", In: "query", Type: "[]string"},
+		{Name: "X-Limit", In: "header", Type: "*int64"},
+	}
+	assignParamNames(params)
+
+	if got := requiredParams(params); len(got) != 1 || got[0].Name != "category" {
+		t.Errorf("requiredParams() = %+v, want only category", got)
+	}
+
+	tests := []struct {
+		name      string
+		wantType  string
+		wantValue string
+	}{
+		{"page", "*int64", "*params.Page"},
+		{"tags", "[]string", "params.Tags"},
+		{"X-Limit", "*int64", "*params.XLimit"},
+	}
+
+	optional := optionalParams(params)
+	if len(optional) != len(tests) {
+		t.Fatalf("optionalParams() returned %d params, want %d", len(optional), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if optional[i].Name != tt.name {
+				t.Fatalf("optionalParams()[%d] = %s, want %s", i, optional[i].Name, tt.name)
+			}
+			if got := optionalParamType(optional[i].Type); got != tt.wantType {
+				t.Errorf("optionalParamType() = %s, want %s", got, tt.wantType)
+			}
+			if got := optionalParamValue(optional[i]); got != tt.wantValue {
+				t.Errorf("optionalParamValue() = %s, want %s", got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestParamOption(t *testing.T) {
+	tests := []struct {
+		param Parameter
+		value string
+		want  string
+	}{
+		{Parameter{Name: "page", In: "query", Style: "form", Explode: true}, "*params.Page", `client.WithQueryValues(client.QueryParam("page", *params.Page, client.StyleForm, true))`},
+		{Parameter{Name: "ids", In: "query", Style: "pipeDelimited"}, "params.Ids", `client.WithQueryValues(client.QueryParam("ids", params.Ids, client.StylePipeDelimited, false))`},
+		{Parameter{Name: "filter", In: "query", Style: "deepObject", Explode: true}, "params.Filter", `client.WithQueryValues(client.QueryParam("filter", params.Filter, client.StyleDeepObject, true))`},
+		{Parameter{Name: "X-Trace", In: "header", Style: "simple"}, "xTrace", `client.WithHeader("X-Trace", client.HeaderParam(xTrace, false))`},
+		{Parameter{Name: "session", In: "cookie", Style: "form", Explode: true}, "session", `client.WithCookie("session", client.CookieParam(session, true))`},
+	}
+
+	for _, tt := range tests {
+		if got := paramOption(tt.param, tt.value); got != tt.want {
+			t.Errorf("paramOption() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestSuccessResultType(t *testing.T) {
+	tests := []struct {
+		name string
+		op   Operation
+		want string
+	}{
+		{
+			name: "model response",
+			op:   Operation{Name: "GetUser", SuccessResponse: &Response{StatusCode: "200", Type: "User"}},
+			want: "*User",
+		},
+		{
+			name: "slice response",
+			op:   Operation{Name: "ListUsers", SuccessResponse: &Response{StatusCode: "200", Type: "[]User"}},
+			want: "[]User",
+		},
+		{
+			name: "map response",
+			op:   Operation{Name: "GetCounts", SuccessResponse: &Response{StatusCode: "200", Type: "map[string]int64"}},
+			want: "map[string]int64",
+		},
+		{
+			name: "no content",
+			op:   Operation{Name: "DeleteUser", SuccessResponse: &Response{StatusCode: "204"}},
+			want: "",
+		},
+		{
+			name: "no success response",
+			op:   Operation{Name: "Ping"},
+			want: "",
+		},
+		{
+			name: "multiple success responses",
+			op: Operation{
+				Name:                        "CreateAsset",
+				SuccessResponse:             &Response{StatusCode: "200", Type: "Asset"},
+				HasMultipleSuccessResponses: true,
+			},
+			want: "*CreateAssetResponseWrapper",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := successResultType(tt.op); got != tt.want {
+				t.Errorf("successResultType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedErrorResponses(t *testing.T) {
+	op := Operation{
+		ErrorResponses: []Response{
+			{StatusCode: "500", Type: "ServerError"},
+			{StatusCode: "403"},
+			{StatusCode: "400", Type: "ValidationError"},
+		},
+	}
+
+	got := typedErrorResponses(op)
+	if len(got) != 2 {
+		t.Fatalf("typedErrorResponses() returned %d responses, want 2", len(got))
+	}
+	if got[0].StatusCode != "400" || got[1].StatusCode != "500" {
+		t.Errorf("typedErrorResponses() order = [%s %s], want [400 500]", got[0].StatusCode, got[1].StatusCode)
+	}
+}
+
+func TestStatusCodeCondition(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"404", "status == 404"},
+		{"4XX", "status >= 400 && status < 500"},
+		{"5xx", "status >= 500 && status < 600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := statusCodeCondition(tt.code, "status"); got != tt.want {
+				t.Errorf("statusCodeCondition(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGoDoc(t *testing.T) {
 	tests := []struct {
 		name   string
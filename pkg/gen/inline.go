@@ -0,0 +1,126 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// inlineRegistry tracks the Go type names in use and the models synthesized
+// from inline schemas, so that every inline schema gets a single, stable name
+type inlineRegistry struct {
+	// names holds every reserved Go type name
+	names map[string]bool
+	// bySchema maps an inline schema to the name of the model generated for it
+	bySchema map[*openapi3.Schema]string
+	// pending holds synthesized models not yet returned by takeInlineModels
+	pending []Model
+	// componentsReserved is set once component schema names are reserved
+	componentsReserved bool
+}
+
+// reserveComponentNames reserves the names of all component schemas, and of
+// the generated client, so that inline models never shadow them
+func (g *Generator) reserveComponentNames() {
+	if g.inline.componentsReserved {
+		return
+	}
+	g.inline.componentsReserved = true
+
+	if g.config != nil && g.config.ClientName != "" {
+		g.reserveTypeName(g.config.ClientName)
+	}
+	if g.spec.Components == nil {
+		return
+	}
+	for _, name := range sortedSchemaNames(g.spec.Components.Schemas) {
+		g.reserveTypeName(toPascalCase(name))
+	}
+}
+
+// reserveTypeName returns base, or base followed by the smallest counter that
+// makes it unique, and marks the result as taken
+func (g *Generator) reserveTypeName(base string) string {
+	if g.inline.names == nil {
+		g.inline.names = make(map[string]bool)
+	}
+
+	name := base
+	for counter := 2; g.inline.names[name]; counter++ {
+		name = fmt.Sprintf("%s%d", base, counter)
+	}
+	g.inline.names[name] = true
+	return name
+}
+
+// inlineModel returns the name of the model generated for an inline schema,
+// creating the model under a name derived from scope on first use
+func (g *Generator) inlineModel(scope string, schema *openapi3.Schema) string {
+	if g.inline.bySchema == nil {
+		g.inline.bySchema = make(map[*openapi3.Schema]string)
+	}
+	if name, ok := g.inline.bySchema[schema]; ok {
+		return name
+	}
+
+	name := g.reserveTypeName(scope)
+	// Register before building the model so nested references resolve to it
+	g.inline.bySchema[schema] = name
+
+	// Claim a slot first so the model precedes the models nested in it
+	index := len(g.inline.pending)
+	g.inline.pending = append(g.inline.pending, Model{Name: name})
+
+	model := g.schemaToModel(name, schema)
+	model.Name = name
+	g.inline.pending[index] = *model
+
+	return name
+}
+
+// takeInlineModels returns the models synthesized since the last call
+func (g *Generator) takeInlineModels() []Model {
+	models := g.inline.pending
+	g.inline.pending = nil
+	return models
+}
+
+// sortedSchemaNames returns the names of a schema map in sorted order
+func sortedSchemaNames(schemas openapi3.Schemas) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedPropertyNames returns the property names of a schema in the order
+// they are declared in the document. Properties without a known position,
+// such as those of schemas built in code, follow in alphabetical order.
+func sortedPropertyNames(schema *openapi3.Schema) []string {
+	position := make(map[string]int)
+	for i, name := range stringListExtension(schema, propertyOrderKey) {
+		position[name] = i + 1
+	}
+	names := sortedSchemaNames(schema.Properties)
+	sort.SliceStable(names, func(i, j int) bool {
+		pi, pj := position[names[i]], position[names[j]]
+		if pi == 0 || pj == 0 {
+			return pi != 0 && pj == 0
+		}
+		return pi < pj
+	})
+	return names
+}
+
+// sortedPaths returns the paths of a document in sorted order
+func sortedPaths(paths *openapi3.Paths) []string {
+	keys := make([]string, 0, paths.Len())
+	for path := range paths.Map() {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	return keys
+}
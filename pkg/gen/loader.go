@@ -0,0 +1,177 @@
+package gen
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// loadDocument loads an OpenAPI document, recording the order of the
+// properties of its schemas, and of those in the files it references, so that
+// generated code can follow the document's order
+func loadDocument(loader *openapi3.Loader, data []byte, specPath string) (*openapi3.T, error) {
+	node, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if node != nil {
+		if data, err = encodeDocument(node, true); err != nil {
+			return nil, err
+		}
+	}
+
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := openapi3.DefaultReadFromURI(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return data, nil
+		}
+		return encodeDocument(&node, true)
+	}
+
+	return loader.LoadFromDataWithPath(data, &url.URL{Path: filepath.ToSlash(specPath)})
+}
+
+// propertyOrderKey is the schema extension recording the order properties
+// are declared in, as the loader keeps them in a map
+const propertyOrderKey = "x-oapix-property-order"
+
+// ConvertSpec reads a Swagger 2.0, OpenAPI 3.0 or OpenAPI 3.1 document and
+// returns the OpenAPI 3.0 document the generator works from
+func ConvertSpec(specPath string) ([]byte, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+
+	data, err = prepareDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the converted document is one the generator accepts
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	spec, err := loader.LoadFromDataWithPath(data, &url.URL{Path: filepath.ToSlash(specPath)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	if err := spec.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	return data, nil
+}
+
+// prepareDocument converts Swagger 2.0 and OpenAPI 3.1 documents to their
+// OpenAPI 3.0 equivalent, re-encoding every document as block style YAML
+func prepareDocument(data []byte) ([]byte, error) {
+	node, err := parseDocument(data)
+	if err != nil || node == nil {
+		return data, err
+	}
+	return encodeDocument(node, false)
+}
+
+// parseDocument parses a document and converts Swagger 2.0 and OpenAPI 3.1
+// documents to their OpenAPI 3.0 equivalent. It returns a nil node for
+// syntax errors, which are left for the loader to report.
+func parseDocument(data []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, nil
+	}
+
+	if isSwagger2(&node) {
+		doc, err := convertSwagger2(&node)
+		if err != nil {
+			return nil, err
+		}
+		node = *doc
+	}
+	downgradeOpenAPI31(&node)
+	return &node, nil
+}
+
+// encodeDocument encodes a parsed document as block style YAML, recording
+// the order of the properties of its schemas when markOrder is set
+func encodeDocument(node *yaml.Node, markOrder bool) ([]byte, error) {
+	if markOrder {
+		markPropertyOrder(node)
+	}
+	normalizeStyle(node)
+
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert document: %w", err)
+	}
+	return out, nil
+}
+
+// nameMapKeys are the keys of mappings from names to objects, whose own keys
+// aren't keywords such as properties or default
+var nameMapKeys = map[string]bool{
+	"paths": true, "webhooks": true, "callbacks": true, "schemas": true, "properties": true,
+	"parameters": true, "requestBodies": true, "responses": true, "headers": true,
+	"content": true, "encoding": true, "links": true, "securitySchemes": true,
+	"variables": true, "definitions": true, "$defs": true, "patternProperties": true,
+}
+
+// literalKeys hold values rather than objects, which may contain any keys
+var literalKeys = map[string]bool{
+	"example": true, "examples": true, "default": true, "enum": true, "const": true,
+}
+
+// markPropertyOrder sets the propertyOrderKey extension of every schema
+// under node that declares properties
+func markPropertyOrder(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			markPropertyOrder(child)
+		}
+	case yaml.MappingNode:
+		if properties := mappingValue(node, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+			order := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for _, name := range mappingKeys(properties) {
+				order.Content = append(order.Content, stringNode(name))
+			}
+			setMappingValue(node, propertyOrderKey, order)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch {
+			case literalKeys[key] || strings.HasPrefix(key, "x-"):
+			case nameMapKeys[key] && value.Kind == yaml.MappingNode:
+				for j := 1; j < len(value.Content); j += 2 {
+					markPropertyOrder(value.Content[j])
+				}
+			default:
+				markPropertyOrder(value)
+			}
+		}
+	}
+}
+
+// normalizeStyle switches all mappings and sequences under node to block
+// style and drops the quotes of strings that do not need them
+func normalizeStyle(node *yaml.Node) {
+	switch {
+	case node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode:
+		node.Style &^= yaml.FlowStyle
+	case node.Kind == yaml.ScalarNode && node.Tag == "!!str":
+		// The encoder still quotes strings that would otherwise read as another type
+		node.Style &^= yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle
+	}
+	for _, child := range node.Content {
+		normalizeStyle(child)
+	}
+}
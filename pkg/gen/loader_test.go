@@ -0,0 +1,223 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const orderedYAMLSpec = `
+openapi: 3.0.0
+info:
+  title: Ordered API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Zone:
+      type: object
+      properties:
+        zeta:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Account'
+        alpha:
+          type: object
+          properties:
+            second:
+              type: string
+            first:
+              type: string
+    Account:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+const orderedFlowSpec = `
+openapi: 3.0.0
+info: {title: Ordered API, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Zone: {type: object, properties: {zeta: {type: string}, owner: {$ref: '#/components/schemas/Account'}, alpha: {type: object, properties: {second: {type: string}, first: {type: string}}}}}
+    Account: {type: object, properties: {name: {type: string}}}
+`
+
+const orderedJSONSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Ordered API", "version": "1.0.0"},
+  "paths": {},
+  "components": {"schemas": {
+    "Zone": {"type": "object", "properties": {
+      "zeta": {"type": "string"},
+      "owner": {"$ref": "#/components/schemas/Account"},
+      "alpha": {"type": "object", "properties": {"second": {"type": "string"}, "first": {"type": "string"}}}
+    }},
+    "Account": {"type": "object", "properties": {"name": {"type": "string"}}}
+  }}
+}`
+
+func TestLoadSpecKeepsPropertyOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		spec string
+	}{
+		{"yaml", "spec.yaml", orderedYAMLSpec},
+		{"flow yaml", "spec.yaml", orderedFlowSpec},
+		{"json", "spec.json", orderedJSONSpec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specPath := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(specPath, []byte(tt.spec), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			gen, err := NewGenerator(&Config{SpecPath: specPath, PackageName: "test"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.LoadSpec(); err != nil {
+				t.Fatal(err)
+			}
+
+			models := gen.extractModels()
+			fieldNames := func(name string) []string {
+				var names []string
+				for _, field := range findModel(t, models, name).Fields {
+					names = append(names, field.JSONName)
+				}
+				return names
+			}
+
+			if got, want := fieldNames("Zone"), []string{"zeta", "owner", "alpha"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Zone fields = %v, want %v", got, want)
+			}
+			if got, want := fieldNames("ZoneAlpha"), []string{"second", "first"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("ZoneAlpha fields = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadSpecKeepsPropertyOrderOfReferencedFiles(t *testing.T) {
+	dir := t.TempDir()
+	spec := `
+openapi: 3.0.0
+info:
+  title: Ordered API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Zone:
+      $ref: 'zone.yaml'
+`
+	zone := `{"type": "object", "properties": {"zeta": {"type": "string"}, "alpha": {"type": "string"}}}`
+	if err := os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "zone.yaml"), []byte(zone), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen, err := NewGenerator(&Config{SpecPath: filepath.Join(dir, "spec.yaml"), PackageName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.LoadSpec(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sortedPropertyNames(gen.spec.Components.Schemas["Zone"].Value), []string{"zeta", "alpha"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Zone properties = %v, want %v", got, want)
+	}
+}
+
+func TestMarkPropertyOrder(t *testing.T) {
+	doc := `
+components:
+  schemas:
+    properties:
+      properties:
+        b: {default: {properties: {z: {}}}}
+        a: {}
+  responses:
+    default:
+      content:
+        application/json:
+          schema:
+            properties: {d: {}, c: {}}
+`
+	// Schemas are told apart from names and values, such as the schema named
+	// properties, the default response and the default of b
+	want := `components:
+    schemas:
+        properties:
+            properties:
+                b:
+                    default:
+                        properties:
+                            z: {}
+                a: {}
+            x-oapix-property-order:
+                - b
+                - a
+    responses:
+        default:
+            content:
+                application/json:
+                    schema:
+                        properties:
+                            d: {}
+                            c: {}
+                        x-oapix-property-order:
+                            - d
+                            - c
+`
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(doc), &node); err != nil {
+		t.Fatal(err)
+	}
+	got, err := encodeDocument(&node, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("encodeDocument() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	spec := orderedYAMLSpec + `
+    Status:
+      type: string
+      enum: [b, a]
+`
+	first := generateFromSpec(t, spec, &Config{GenerateModels: true, GenerateClient: true})
+	for i := 0; i < 5; i++ {
+		again := generateFromSpec(t, spec, &Config{GenerateModels: true, GenerateClient: true})
+		if !reflect.DeepEqual(first, again) {
+			t.Fatal("generated output differs between runs")
+		}
+	}
+
+	// Models are sorted by name
+	models := first["models.go"]
+	previous := -1
+	for _, decl := range []string{"type Account struct", "type Status string", "type Zone struct", "type ZoneAlpha struct"} {
+		index := strings.Index(models, decl)
+		if index <= previous {
+			t.Errorf("%q is out of order in models.go", decl)
+		}
+		previous = index
+	}
+}
@@ -0,0 +1,363 @@
+package gen
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// eventStreamMediaType is the media type of server-sent event responses
+const eventStreamMediaType = "text/event-stream"
+
+// isJSONMediaType reports whether a media type is JSON, such as
+// application/json; charset=utf-8 or a +json type like application/problem+json
+func isJSONMediaType(mediaType string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return base == "application/json" || strings.HasSuffix(base, "+json")
+}
+
+// isXMLMediaType reports whether a media type is XML, such as text/xml or
+// a +xml type like application/atom+xml
+func isXMLMediaType(mediaType string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return base == "application/xml" || base == "text/xml" || strings.HasSuffix(base, "+xml")
+}
+
+// splitMediaTypes splits the media types of content into those handled by
+// codecs, JSON types first with application/json leading, and the others,
+// in name order. The */* wildcard is in neither.
+func splitMediaTypes(content openapi3.Content) (codecTypes, rawTypes []string) {
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	var jsonTypes, xmlTypes []string
+	for _, mediaType := range mediaTypes {
+		switch {
+		case mediaType == "application/json":
+			jsonTypes = append([]string{mediaType}, jsonTypes...)
+		case isJSONMediaType(mediaType):
+			jsonTypes = append(jsonTypes, mediaType)
+		case isXMLMediaType(mediaType):
+			xmlTypes = append(xmlTypes, mediaType)
+		case mediaType != "*/*":
+			rawTypes = append(rawTypes, mediaType)
+		}
+	}
+	return append(jsonTypes, xmlTypes...), rawTypes
+}
+
+// codecContent returns the first media type handled by codecs that has a
+// schema, preferring JSON, or nil when there is none
+func codecContent(content openapi3.Content) (string, *openapi3.MediaType) {
+	codecTypes, _ := splitMediaTypes(content)
+	for _, mediaType := range codecTypes {
+		if mt := content[mediaType]; mt != nil && mt.Schema != nil {
+			return mediaType, mt
+		}
+	}
+	return "", nil
+}
+
+// isTextMediaType reports whether content of the media type is returned as a string
+func isTextMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") && !isEventStreamMediaType(mediaType)
+}
+
+// isEventStreamMediaType reports whether content of the media type is read
+// as server-sent events
+func isEventStreamMediaType(mediaType string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	return err == nil && base == eventStreamMediaType
+}
+
+// isNDJSONMediaType reports whether content of the media type is a stream of
+// newline-delimited JSON records, such as application/x-ndjson
+func isNDJSONMediaType(mediaType string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	switch base {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines", "application/jsonlines":
+		return true
+	}
+	return false
+}
+
+// recordType returns the type the records of newline-delimited JSON content
+// are decoded from or encoded as: the items of an array schema, which some
+// specs use to describe the stream, or else the schema itself. Records
+// without a schema are json.RawMessage.
+func (g *Generator) recordType(content *openapi3.MediaType, scope string) string {
+	if content == nil || content.Schema == nil {
+		return "json.RawMessage"
+	}
+	if schema := content.Schema.Value; schema != nil && schema.Type.Is("array") && schema.Items != nil {
+		return g.schemaRefToGoTypeInScope(schema.Items, "", scope)
+	}
+	return g.schemaRefToGoTypeInScope(content.Schema, "", scope)
+}
+
+// eventType returns the type the data of server-sent events is decoded
+// into: the type of the content's schema, or client.Event for the events
+// themselves when it has none
+func (g *Generator) eventType(content *openapi3.MediaType, scope string) string {
+	if content == nil || content.Schema == nil {
+		return "client.Event"
+	}
+	return g.schemaRefToGoTypeInScope(content.Schema, "", scope)
+}
+
+// mediaTypeSuffix returns the suffix naming the method variant of an
+// operation that sends or accepts a media type, such as XML or CSV
+func mediaTypeSuffix(mediaType string) string {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		base = mediaType
+	}
+	switch base {
+	case multipartMediaType:
+		return "Multipart"
+	case formMediaType:
+		return "Form"
+	case "text/plain":
+		return "Text"
+	case "application/octet-stream":
+		return "Binary"
+	case eventStreamMediaType:
+		return "Events"
+	}
+	if isNDJSONMediaType(base) {
+		return "NDJSON"
+	}
+
+	subtype := base[strings.Index(base, "/")+1:]
+	if i := strings.Index(subtype, "+"); i > 0 {
+		subtype = subtype[:i]
+	}
+	subtype = strings.TrimPrefix(strings.TrimPrefix(subtype, "x-"), "vnd.")
+	// Short subtypes are format acronyms, like CSV, PDF or YAML
+	if len(subtype) <= 4 {
+		return strings.ToUpper(subtype)
+	}
+	return toPascalCase(subtype)
+}
+
+// assignVariantNames makes the method names of media type variants unique
+// among the methods of all operations
+func assignVariantNames(operations []Operation) {
+	used := make(map[string]bool)
+	for _, op := range operations {
+		if op.Variant == "" {
+			used[op.Name] = true
+		}
+	}
+	for i := range operations {
+		op := &operations[i]
+		if op.Variant == "" {
+			continue
+		}
+		base := op.Variant
+		for counter := 2; used[op.Name+op.Variant]; counter++ {
+			op.Variant = fmt.Sprintf("%s%d", base, counter)
+		}
+		used[op.Name+op.Variant] = true
+	}
+}
+
+// declaresXML reports whether any request body or response of the spec has
+// an XML media type, which makes models carry xml struct tags
+func (g *Generator) declaresXML() bool {
+	if g.spec.Paths == nil {
+		return false
+	}
+	for _, path := range sortedPaths(g.spec.Paths) {
+		pathItem := g.spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, mo := range pathItemOperations(pathItem) {
+			var contents []openapi3.Content
+			if rb := mo.Operation.RequestBody; rb != nil && rb.Value != nil {
+				contents = append(contents, rb.Value.Content)
+			}
+			if mo.Operation.Responses != nil {
+				for _, responseRef := range mo.Operation.Responses.Map() {
+					if responseRef.Value != nil {
+						contents = append(contents, responseRef.Value.Content)
+					}
+				}
+			}
+			for _, content := range contents {
+				for mediaType := range content {
+					if isXMLMediaType(mediaType) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// xmlTag returns the xml struct tag of an object property, without
+// omitempty, honoring the name, attribute and wrapped settings of its xml object
+func xmlTag(propName string, schema *openapi3.Schema) string {
+	name := propName
+	attribute := false
+	if schema.XML != nil {
+		if schema.XML.Name != "" {
+			name = schema.XML.Name
+		}
+		attribute = schema.XML.Attribute
+	}
+
+	// Array items are named by their own xml object, within a wrapper
+	// element named after the property when wrapped
+	if schema.Type.Is("array") && schema.Items != nil && schema.Items.Value != nil {
+		item := name
+		if schema.Items.Value.XML != nil && schema.Items.Value.XML.Name != "" {
+			item = schema.Items.Value.XML.Name
+		}
+		if schema.XML != nil && schema.XML.Wrapped {
+			name += ">" + item
+		} else {
+			name = item
+		}
+	}
+
+	if attribute {
+		return name + ",attr"
+	}
+	return name
+}
+
+// requestBodies returns the request bodies of an operation that the client
+// can send, in order of preference: JSON, NDJSON, multipart, form and then
+// XML. The first is the operation's own, the others are sent by media type
+// variants.
+func (g *Generator) requestBodies(opName string, rb *openapi3.RequestBody) []*RequestBody {
+	var bodies []*RequestBody
+	add := func(mediaType string, content *openapi3.MediaType, encodings []PartEncoding) {
+		// Inline schemas of variants are named after them, as they may differ
+		scope := opName + "Request"
+		if len(bodies) > 0 {
+			scope = opName + mediaTypeSuffix(mediaType) + "Request"
+		}
+		goType := ""
+		if isNDJSONMediaType(mediaType) {
+			goType = "iter.Seq[" + g.recordType(content, scope) + "]"
+		} else if mediaType == multipartMediaType {
+			goType = g.multipartBodyType(content.Schema, scope)
+		} else {
+			goType = g.schemaRefToGoTypeInScope(content.Schema, "", scope)
+		}
+		bodies = append(bodies, &RequestBody{
+			Type:        goType,
+			Description: rb.Description,
+			Required:    rb.Required,
+			MediaType:   mediaType,
+			Encodings:   encodings,
+		})
+	}
+
+	var jsonType, xmlType string
+	codecTypes, rawTypes := splitMediaTypes(rb.Content)
+	for _, mediaType := range codecTypes {
+		if rb.Content[mediaType].Schema == nil {
+			continue
+		}
+		if jsonType == "" && isJSONMediaType(mediaType) {
+			jsonType = mediaType
+		} else if xmlType == "" && isXMLMediaType(mediaType) {
+			xmlType = mediaType
+		}
+	}
+
+	if jsonType != "" {
+		add(jsonType, rb.Content[jsonType], nil)
+	}
+	for _, mediaType := range rawTypes {
+		if isNDJSONMediaType(mediaType) {
+			add(mediaType, rb.Content[mediaType], nil)
+			break
+		}
+	}
+	for _, mediaType := range []string{multipartMediaType, formMediaType} {
+		if content := rb.Content.Get(mediaType); content != nil && content.Schema != nil {
+			add(mediaType, content, partEncodings(content))
+		}
+	}
+	if xmlType != "" {
+		add(xmlType, rb.Content[xmlType], nil)
+	}
+	return bodies
+}
+
+// mediaTypeVariants returns the variants of an operation sending its other
+// request bodies, and accepting the other media types of its success
+// response that are returned as strings or streams
+func mediaTypeVariants(op Operation, bodies []*RequestBody, rawTypes []string) []Operation {
+	var variants []Operation
+	for _, body := range bodies {
+		variant := op
+		variant.Variant = mediaTypeSuffix(body.MediaType)
+		variant.VariantMediaType = body.MediaType
+		variant.RequestBody = body
+		variants = append(variants, variant)
+	}
+
+	if op.HasMultipleSuccessResponses || op.SuccessResponse == nil || op.SuccessResponse.Type == "" {
+		return variants
+	}
+	for _, mediaType := range rawTypes {
+		variant := op
+		variant.Variant = mediaTypeSuffix(mediaType)
+		variant.VariantMediaType = mediaType
+		success := *op.SuccessResponse
+		success.Type = ""
+		success.MediaType = mediaType
+		success.MediaTypes = []string{mediaType}
+		variant.SuccessResponse = &success
+		variant.Responses = make(map[string]Response, len(op.Responses))
+		for statusCode, resp := range op.Responses {
+			variant.Responses[statusCode] = resp
+		}
+		variant.Responses[success.StatusCode] = success
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// variantDoc describes what a media type variant of an operation does
+// differently, or returns "" for operations that aren't variants
+func variantDoc(op Operation) string {
+	switch {
+	case op.Variant == "":
+		return ""
+	case op.RequestBody != nil && op.RequestBody.MediaType == op.VariantMediaType:
+		return fmt.Sprintf("%s sends the request body as %s.", op.Name+op.Variant, op.VariantMediaType)
+	}
+	return fmt.Sprintf("%s accepts the response as %s.", op.Name+op.Variant, op.VariantMediaType)
+}
+
+// xmlRootName returns the XML root element of a model, which is the name of
+// its xml object or else its schema name
+func xmlRootName(name string, schema *openapi3.Schema) string {
+	if schema.XML != nil && schema.XML.Name != "" {
+		return schema.XML.Name
+	}
+	return name
+}
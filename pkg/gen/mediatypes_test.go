@@ -0,0 +1,369 @@
+package gen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestIsJSONMediaType(t *testing.T) {
+	tests := map[string]bool{
+		"application/json":                true,
+		"application/json; charset=utf-8": true,
+		"Application/JSON":                true,
+		"application/problem+json":        true,
+		"application/vnd.api+json":        true,
+		"application/merge-patch+json":    true,
+		"application/xml":                 false,
+		"text/plain":                      false,
+		"application/jsonl":               false,
+		"":                                false,
+	}
+	for mediaType, want := range tests {
+		if got := isJSONMediaType(mediaType); got != want {
+			t.Errorf("isJSONMediaType(%q) = %v, want %v", mediaType, got, want)
+		}
+	}
+}
+
+func TestCodecContent(t *testing.T) {
+	withSchema := func() *openapi3.MediaType {
+		mt := openapi3.NewMediaType()
+		mt.Schema = openapi3.NewStringSchema().NewRef()
+		return mt
+	}
+
+	tests := []struct {
+		name    string
+		content openapi3.Content
+		want    string
+	}{
+		{
+			name:    "prefers application/json",
+			content: openapi3.Content{"application/hal+json": withSchema(), "application/json": withSchema()},
+			want:    "application/json",
+		},
+		{
+			name:    "parameterized",
+			content: openapi3.Content{"application/json; charset=utf-8": withSchema()},
+			want:    "application/json; charset=utf-8",
+		},
+		{
+			name:    "first +json type in name order",
+			content: openapi3.Content{"application/xml": withSchema(), "application/vnd.api+json": withSchema(), "application/hal+json": withSchema()},
+			want:    "application/hal+json",
+		},
+		{
+			name:    "XML without JSON",
+			content: openapi3.Content{"text/csv": withSchema(), "application/xml": withSchema()},
+			want:    "application/xml",
+		},
+		{
+			name:    "without schema",
+			content: openapi3.Content{"application/json": openapi3.NewMediaType()},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := codecContent(tt.content); got != tt.want {
+				t.Errorf("codecContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitMediaTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		mediaTypes []string
+		wantCodec  []string
+		wantRaw    []string
+	}{
+		{
+			name:       "JSON first",
+			mediaTypes: []string{"text/xml", "application/hal+json", "application/json", "text/csv"},
+			wantCodec:  []string{"application/json", "application/hal+json", "text/xml"},
+			wantRaw:    []string{"text/csv"},
+		},
+		{
+			name:       "raw in name order",
+			mediaTypes: []string{"image/png", "image/jpeg", "text/plain"},
+			wantRaw:    []string{"image/jpeg", "image/png", "text/plain"},
+		},
+		{
+			name:       "wildcard",
+			mediaTypes: []string{"*/*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := openapi3.Content{}
+			for _, mediaType := range tt.mediaTypes {
+				content[mediaType] = openapi3.NewMediaType()
+			}
+			codecTypes, rawTypes := splitMediaTypes(content)
+			if !reflect.DeepEqual(codecTypes, tt.wantCodec) || !reflect.DeepEqual(rawTypes, tt.wantRaw) {
+				t.Errorf("splitMediaTypes() = %v, %v, want %v, %v", codecTypes, rawTypes, tt.wantCodec, tt.wantRaw)
+			}
+		})
+	}
+}
+
+func TestMediaTypeSuffix(t *testing.T) {
+	tests := map[string]string{
+		"application/xml":                   "XML",
+		"text/csv":                          "CSV",
+		"application/x-yaml":                "YAML",
+		"application/pdf":                   "PDF",
+		"text/plain":                        "Text",
+		"application/octet-stream":          "Binary",
+		"multipart/form-data":               "Multipart",
+		"application/x-www-form-urlencoded": "Form",
+		"application/vnd.ms-excel":          "MsExcel",
+		"application/merge-patch+json":      "MergePatch",
+		"application/rss+xml":               "RSS",
+		"application/x-ndjson":              "NDJSON",
+		"application/jsonl":                 "NDJSON",
+		"text/event-stream":                 "Events",
+	}
+	for mediaType, want := range tests {
+		if got := mediaTypeSuffix(mediaType); got != want {
+			t.Errorf("mediaTypeSuffix(%q) = %q, want %q", mediaType, got, want)
+		}
+	}
+}
+
+func TestXMLTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi3.Schema
+		want   string
+	}{
+		{name: "property name", schema: openapi3.NewStringSchema(), want: "name"},
+		{name: "renamed", schema: &openapi3.Schema{Type: &openapi3.Types{"string"}, XML: &openapi3.XML{Name: "title"}}, want: "title"},
+		{name: "attribute", schema: &openapi3.Schema{Type: &openapi3.Types{"integer"}, XML: &openapi3.XML{Attribute: true}}, want: "name,attr"},
+		{
+			name:   "unwrapped array",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{XML: &openapi3.XML{Name: "tag"}}}},
+			want:   "tag",
+		},
+		{
+			name:   "wrapped array",
+			schema: &openapi3.Schema{Type: &openapi3.Types{"array"}, XML: &openapi3.XML{Wrapped: true}, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{XML: &openapi3.XML{Name: "tag"}}}},
+			want:   "name>tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := xmlTag("name", tt.schema); got != tt.want {
+				t.Errorf("xmlTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateJSONMediaTypes(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Users API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The user
+          content:
+            application/hal+json:
+              schema:
+                $ref: '#/components/schemas/User'
+        '404':
+          description: Not found
+          content:
+            application/problem+json:
+              schema:
+                $ref: '#/components/schemas/Problem'
+    patch:
+      operationId: patchUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/merge-patch+json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '200':
+          description: The user
+          content:
+            application/json; charset=utf-8:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: Users
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+    Problem:
+      type: object
+      properties:
+        detail:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"GetUser(ctx context.Context, id string) (*User, error)",
+		"Status404 *Problem",
+		`client.WithAccept("application/hal+json", "application/problem+json")`,
+		"PatchUser(ctx context.Context, id string, req User) (*User, error)",
+		`client.WithContentType("application/merge-patch+json")`,
+		`client.WithAccept("application/json; charset=utf-8")`,
+		`c.RequestJSON(ctx, "PATCH", path, req, opts...)`,
+		`c.Request(ctx, "GET", path, nil)`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateMediaTypeVariants(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Pets API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: Pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+            application/xml:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+            text/csv:
+              schema:
+                type: string
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '400':
+          description: Invalid
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      xml:
+        name: pet
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        name:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	modelsStr := files["models.go"]
+	clientStr := files["client.go"]
+
+	expectedModels := []string{
+		`XMLName xml.Name ` + "`" + `json:"-" xml:"pet"` + "`",
+		`json:"id,omitempty" xml:"id,attr,omitempty"`,
+		`"encoding/xml"`,
+	}
+	for _, exp := range expectedModels {
+		if !strings.Contains(modelsStr, exp) {
+			t.Errorf("models.go should contain %q", exp)
+		}
+	}
+
+	expectedClient := []string{
+		"ListPets(ctx context.Context) ([]Pet, error)",
+		`client.WithAccept("application/json", "application/xml")`,
+		"ListPetsCSV(ctx context.Context) (string, error)",
+		"ListPetsCSV accepts the response as text/csv.",
+		`client.WithAccept("text/csv")`,
+		"CreatePetXML(ctx context.Context, req Pet) (*Pet, error)",
+		"CreatePetXML sends the request body as application/xml.",
+		"requester, ok := c.Client.(client.EncodedRequester)",
+		`requester.RequestEncoded(ctx, "POST", path, req, opts...)`,
+		`client.WithContentType("application/xml")`,
+		"return nil, decodeCreatePetError(err)",
+	}
+	for _, exp := range expectedClient {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+
+	// Variants share the types generated for their operation
+	if n := strings.Count(clientStr, "type CreatePetError struct"); n != 1 {
+		t.Errorf("CreatePetError declared %d times, want 1", n)
+	}
+}
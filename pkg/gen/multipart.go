@@ -0,0 +1,304 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Media types of request bodies sent as multipart and form data
+const (
+	multipartMediaType = "multipart/form-data"
+	formMediaType      = "application/x-www-form-urlencoded"
+)
+
+// PartEncoding describes how a property of a multipart or form body is sent
+type PartEncoding struct {
+	Name        string
+	ContentType string
+	// Headers maps part headers to the default values declared for them
+	Headers map[string]string
+	// Style and Explode serialize form properties; Style is empty by default
+	Style   string
+	Explode bool
+}
+
+// markMultipartSchemas records the object schemas used as multipart request
+// bodies, whose binary properties become client.File fields, including those
+// of their allOf members. Component schemas also used elsewhere, such as in
+// JSON bodies, are left unmarked; their multipart bodies get a separate type
+// from multipartBodyType instead.
+func (g *Generator) markMultipartSchemas() {
+	if g.multipartSchemas != nil {
+		return
+	}
+	g.multipartSchemas = make(map[*openapi3.Schema]bool)
+	if g.spec.Paths == nil {
+		return
+	}
+
+	shared := g.sharedSchemas()
+	for _, path := range sortedPaths(g.spec.Paths) {
+		pathItem := g.spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, mo := range pathItemOperations(pathItem) {
+			rb := mo.Operation.RequestBody
+			if rb == nil || rb.Value == nil {
+				continue
+			}
+			content := rb.Value.Content.Get(multipartMediaType)
+			if content == nil || content.Schema == nil || content.Schema.Value == nil {
+				continue
+			}
+			if content.Schema.Ref == "" || !shared[content.Schema.Value] {
+				g.multipartSchemas[content.Schema.Value] = true
+			}
+		}
+	}
+}
+
+// multipartBodyType returns the Go type of a multipart request body. A
+// component schema with binary properties that is also used elsewhere gets a
+// separate model, named after it with a Multipart suffix, holding the
+// client.File fields its own model can't have.
+func (g *Generator) multipartBodyType(schemaRef *openapi3.SchemaRef, scope string) string {
+	g.markMultipartSchemas()
+	if schemaRef.Ref == "" || schemaRef.Value == nil || g.multipartSchemas[schemaRef.Value] ||
+		!hasFileProperties(schemaRef.Value) {
+		return g.schemaRefToGoTypeInScope(schemaRef, "", scope)
+	}
+
+	if g.multipartCopies == nil {
+		g.multipartCopies = make(map[*openapi3.Schema]*openapi3.Schema)
+	}
+	schema, ok := g.multipartCopies[schemaRef.Value]
+	if !ok {
+		copied := *schemaRef.Value
+		schema = &copied
+		g.multipartCopies[schemaRef.Value] = schema
+		g.multipartSchemas[schema] = true
+	}
+	return g.inlineModel(g.schemaRefToGoType(schemaRef)+"Multipart", schema)
+}
+
+// hasFileProperties reports whether an object schema, or one of its allOf
+// members, has properties sent as files in multipart bodies
+func hasFileProperties(schema *openapi3.Schema) bool {
+	for _, propRef := range schema.Properties {
+		if propRef != nil && propRef.Value != nil && fileFieldType(propRef.Value) != "" {
+			return true
+		}
+	}
+	for _, member := range schema.AllOf {
+		if member != nil && member.Value != nil && hasFileProperties(member.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedSchemas returns the schemas referenced anywhere in the spec other
+// than as the schema of a multipart request body
+func (g *Generator) sharedSchemas() map[*openapi3.Schema]bool {
+	shared := make(map[*openapi3.Schema]bool)
+	visited := make(map[*openapi3.Schema]bool)
+
+	var walk func(schemaRef *openapi3.SchemaRef)
+	walkSchema := func(schema *openapi3.Schema) {
+		if visited[schema] {
+			return
+		}
+		visited[schema] = true
+		for _, propRef := range schema.Properties {
+			walk(propRef)
+		}
+		walk(schema.Items)
+		walk(schema.AdditionalProperties.Schema)
+		walk(schema.Not)
+		for _, members := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+			for _, member := range members {
+				walk(member)
+			}
+		}
+	}
+	walk = func(schemaRef *openapi3.SchemaRef) {
+		if schemaRef == nil || schemaRef.Value == nil {
+			return
+		}
+		if schemaRef.Ref != "" {
+			shared[schemaRef.Value] = true
+		}
+		walkSchema(schemaRef.Value)
+	}
+	walkContent := func(content openapi3.Content, skip *openapi3.MediaType) {
+		for _, mediaType := range content {
+			if mediaType != nil && mediaType != skip {
+				walk(mediaType.Schema)
+			}
+		}
+	}
+	walkParameters := func(params openapi3.Parameters) {
+		for _, paramRef := range params {
+			if paramRef != nil && paramRef.Value != nil {
+				walk(paramRef.Value.Schema)
+				walkContent(paramRef.Value.Content, nil)
+			}
+		}
+	}
+
+	if g.spec.Components != nil {
+		for _, schemaRef := range g.spec.Components.Schemas {
+			if schemaRef != nil && schemaRef.Value != nil {
+				walkSchema(schemaRef.Value)
+			}
+		}
+	}
+	if g.spec.Paths == nil {
+		return shared
+	}
+	for _, pathItem := range g.spec.Paths.Map() {
+		if pathItem == nil {
+			continue
+		}
+		walkParameters(pathItem.Parameters)
+		for _, mo := range pathItemOperations(pathItem) {
+			op := mo.Operation
+			walkParameters(op.Parameters)
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				content := op.RequestBody.Value.Content
+				walkContent(content, content.Get(multipartMediaType))
+			}
+			if op.Responses == nil {
+				continue
+			}
+			for _, responseRef := range op.Responses.Map() {
+				if responseRef == nil || responseRef.Value == nil {
+					continue
+				}
+				walkContent(responseRef.Value.Content, nil)
+				for _, headerRef := range responseRef.Value.Headers {
+					if headerRef != nil && headerRef.Value != nil {
+						walk(headerRef.Value.Schema)
+					}
+				}
+			}
+		}
+	}
+	return shared
+}
+
+// fileFieldType returns the type of a multipart body property sent as a file,
+// or "" for properties sent as values
+func fileFieldType(schema *openapi3.Schema) string {
+	switch {
+	case schema.Type.Is("string") && schema.Format == "binary":
+		return "client.File"
+	case schema.Type.Is("array") && schema.Items != nil && schema.Items.Value != nil &&
+		schema.Items.Value.Type.Is("string") && schema.Items.Value.Format == "binary":
+		return "[]client.File"
+	}
+	return ""
+}
+
+// partEncodings converts the encoding object of a multipart media type, in
+// property name order
+func partEncodings(mediaType *openapi3.MediaType) []PartEncoding {
+	names := make([]string, 0, len(mediaType.Encoding))
+	for name := range mediaType.Encoding {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var encodings []PartEncoding
+	for _, name := range names {
+		encoding := mediaType.Encoding[name]
+		if encoding == nil {
+			continue
+		}
+		pe := PartEncoding{Name: name, ContentType: encoding.ContentType}
+		if encoding.Style != "" || encoding.Explode != nil {
+			pe.Style = encoding.Style
+			if pe.Style == "" {
+				pe.Style = "form"
+			}
+			// Like parameters, only the form style explodes by default
+			pe.Explode = pe.Style == "form"
+			if encoding.Explode != nil {
+				pe.Explode = *encoding.Explode
+			}
+		}
+		for headerName, headerRef := range encoding.Headers {
+			// Only headers with a default have a value to send
+			if headerRef == nil || headerRef.Value == nil || headerRef.Value.Schema == nil ||
+				headerRef.Value.Schema.Value == nil || headerRef.Value.Schema.Value.Default == nil {
+				continue
+			}
+			if pe.Headers == nil {
+				pe.Headers = make(map[string]string)
+			}
+			pe.Headers[headerName] = fmt.Sprint(headerRef.Value.Schema.Value.Default)
+		}
+		if pe.ContentType != "" || len(pe.Headers) > 0 || pe.Style != "" {
+			encodings = append(encodings, pe)
+		}
+	}
+	return encodings
+}
+
+// partEncodingOption returns the request option applying a part encoding
+func partEncodingOption(pe PartEncoding) string {
+	var fields []string
+	if pe.ContentType != "" {
+		fields = append(fields, fmt.Sprintf("ContentType: %q", pe.ContentType))
+	}
+	if len(pe.Headers) > 0 {
+		names := make([]string, 0, len(pe.Headers))
+		for name := range pe.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		headers := make([]string, len(names))
+		for i, name := range names {
+			headers[i] = fmt.Sprintf("%q: %q", name, pe.Headers[name])
+		}
+		fields = append(fields, fmt.Sprintf("Headers: map[string]string{%s}", strings.Join(headers, ", ")))
+	}
+	if pe.Style != "" {
+		fields = append(fields, fmt.Sprintf("Style: %s, Explode: %t", paramStyle(Parameter{Style: pe.Style}), pe.Explode))
+	}
+	return fmt.Sprintf("client.WithPartEncoding(%q, client.PartEncoding{%s})", pe.Name, strings.Join(fields, ", "))
+}
+
+// optionalRequester returns the optional interface of the client package
+// providing the method that sends a request body, or "" when the method is
+// part of client.Client
+func optionalRequester(rb *RequestBody) string {
+	switch requestBodyMethod(rb) {
+	case "RequestMultipart":
+		return "MultipartRequester"
+	case "RequestEncoded":
+		return "EncodedRequester"
+	}
+	return ""
+}
+
+// requestBodyMethod returns the client method sending a request body
+func requestBodyMethod(rb *RequestBody) string {
+	switch rb.MediaType {
+	case multipartMediaType:
+		return "RequestMultipart"
+	case formMediaType:
+		return "RequestForm"
+	}
+	if isJSONMediaType(rb.MediaType) {
+		return "RequestJSON"
+	}
+	if isNDJSONMediaType(rb.MediaType) {
+		return "Request"
+	}
+	return "RequestEncoded"
+}
@@ -0,0 +1,258 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMultipartRequestBodies(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Upload API
+  version: 1.0.0
+paths:
+  /avatars:
+    post:
+      operationId: uploadAvatar
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              required: [file]
+              properties:
+                file:
+                  type: string
+                  format: binary
+                extras:
+                  type: array
+                  items:
+                    type: string
+                    format: binary
+                caption:
+                  type: string
+            encoding:
+              file:
+                contentType: image/png
+                headers:
+                  X-Upload-Kind:
+                    schema:
+                      type: string
+                      default: avatar
+      responses:
+        '204':
+          description: No content
+  /documents:
+    put:
+      operationId: putDocument
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/DocumentUpload'
+      responses:
+        '204':
+          description: No content
+    post:
+      operationId: createDocument
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Document'
+      responses:
+        '204':
+          description: No content
+components:
+  schemas:
+    DocumentUpload:
+      type: object
+      properties:
+        doc:
+          type: string
+          format: binary
+    Document:
+      type: object
+      properties:
+        content:
+          type: string
+          format: binary
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	modelsStr := files["models.go"]
+	clientStr := files["client.go"]
+
+	expectedModels := []string{
+		"File    client.File",
+		"Extras  []client.File",
+		"Doc *client.File",
+		"Content *string",
+		`"github.com/jmcarbo/oapix/pkg/client"`,
+	}
+	for _, exp := range expectedModels {
+		if !strings.Contains(modelsStr, exp) {
+			t.Errorf("models.go should contain %q", exp)
+		}
+	}
+
+	expectedClient := []string{
+		"UploadAvatar(ctx context.Context, req UploadAvatarRequest) error",
+		`client.WithPartEncoding("file", client.PartEncoding{ContentType: "image/png", Headers: map[string]string{"X-Upload-Kind": "avatar"}})`,
+		"requester, ok := c.Client.(client.MultipartRequester)\n\tif !ok {\n\t\treturn nil, client.ErrNotSupported\n\t}",
+		`requester.RequestMultipart(ctx, "POST", path, req, opts...)`,
+		`requester.RequestMultipart(ctx, "PUT", path, req)`,
+		`c.RequestJSON(ctx, "POST", path, req)`,
+	}
+	for _, exp := range expectedClient {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateMultipartBodiesOfSharedSchemas(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Upload API
+  version: 1.0.0
+paths:
+  /attachments:
+    post:
+      operationId: uploadAttachment
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/Attachment'
+      responses:
+        '204':
+          description: No content
+    put:
+      operationId: putAttachment
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Attachment'
+      responses:
+        '204':
+          description: No content
+  /reports:
+    post:
+      operationId: uploadReport
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/Report'
+      responses:
+        '204':
+          description: No content
+components:
+  schemas:
+    Attachment:
+      type: object
+      properties:
+        data:
+          type: string
+          format: binary
+    Report:
+      allOf:
+        - $ref: '#/components/schemas/Attachment'
+        - type: object
+          properties:
+            title:
+              type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	modelsStr := files["models.go"]
+	clientStr := files["client.go"]
+
+	expectedModels := []string{
+		"type Attachment struct {\n\tData *string `json:\"data,omitempty\"`\n}",
+		"type AttachmentMultipart struct {\n\tData *client.File `json:\"data,omitempty\"`\n}",
+		"type Report struct {\n\tData  *client.File `json:\"data,omitempty\"`",
+	}
+	for _, exp := range expectedModels {
+		if !strings.Contains(modelsStr, exp) {
+			t.Errorf("models.go should contain %q", exp)
+		}
+	}
+
+	expectedClient := []string{
+		"UploadAttachment(ctx context.Context, req AttachmentMultipart) error",
+		"PutAttachment(ctx context.Context, req Attachment) error",
+		"UploadReport(ctx context.Context, req Report) error",
+	}
+	for _, exp := range expectedClient {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateFormRequestBodies(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Auth API
+  version: 1.0.0
+paths:
+  /oauth/token:
+    post:
+      operationId: createToken
+      requestBody:
+        required: true
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              required: [grant_type]
+              properties:
+                grant_type:
+                  type: string
+                scope:
+                  type: array
+                  items:
+                    type: string
+                filter:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+            encoding:
+              scope:
+                style: spaceDelimited
+              filter:
+                style: deepObject
+                explode: true
+      responses:
+        '204':
+          description: No content
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	modelsStr := files["models.go"]
+	clientStr := files["client.go"]
+
+	if !strings.Contains(modelsStr, "type CreateTokenRequest struct") {
+		t.Error("models.go should declare the form body struct")
+	}
+
+	expected := []string{
+		"CreateToken(ctx context.Context, req CreateTokenRequest) error",
+		`client.WithPartEncoding("filter", client.PartEncoding{Style: client.StyleDeepObject, Explode: true})`,
+		`client.WithPartEncoding("scope", client.PartEncoding{Style: client.StyleSpaceDelimited, Explode: false})`,
+		`c.RequestForm(ctx, "POST", path, req, opts...)`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// unsupportedSchemaKeywords are JSON Schema keywords introduced by OpenAPI 3.1
+// that have no 3.0 equivalent and do not affect the generated types
+var unsupportedSchemaKeywords = []string{
+	"$schema", "$id", "$anchor", "$dynamicAnchor", "$dynamicRef", "$comment",
+	"unevaluatedProperties", "unevaluatedItems", "dependentRequired", "dependentSchemas",
+	"patternProperties", "propertyNames", "contains", "minContains", "maxContains",
+	"if", "then", "else",
+}
+
+// downgradeOpenAPI31 rewrites an OpenAPI 3.1 document in place into its
+// OpenAPI 3.0 equivalent, reporting whether the document was a 3.1 one
+func downgradeOpenAPI31(doc *yaml.Node) bool {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	version := mappingValue(root, "openapi")
+	if version == nil || !strings.HasPrefix(version.Value, "3.1") {
+		return false
+	}
+
+	version.Value = "3.0.3"
+	deleteMappingKey(root, "jsonSchemaDialect")
+	if info := mappingValue(root, "info"); info != nil {
+		deleteMappingKey(info, "summary")
+		if license := mappingValue(info, "license"); license != nil {
+			deleteMappingKey(license, "identifier")
+		}
+	}
+	if mappingValue(root, "paths") == nil {
+		setMappingValue(root, "paths", &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+	}
+
+	schemas := componentSchemas(root)
+	if components := mappingValue(root, "components"); components != nil {
+		deleteMappingKey(components, "pathItems")
+	}
+
+	hoistDefs(root, schemas)
+	hoistWebhookPayloads(root, schemas)
+	convertSchemasIn(root)
+
+	return true
+}
+
+// componentSchemas returns the components.schemas mapping, creating it if needed
+func componentSchemas(root *yaml.Node) *yaml.Node {
+	components := mappingValue(root, "components")
+	if components == nil {
+		components = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(root, "components", components)
+	}
+	schemas := mappingValue(components, "schemas")
+	if schemas == nil {
+		schemas = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(components, "schemas", schemas)
+	}
+	return schemas
+}
+
+// hoistDefs moves every $defs entry into components.schemas and points the
+// references to them at their new location
+func hoistDefs(root, schemas *yaml.Node) {
+	moved := make(map[string]string)
+
+	var walk func(node *yaml.Node, pointer string)
+	walk = func(node *yaml.Node, pointer string) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i].Value, node.Content[i+1]
+				walk(value, pointer+"/"+escapePointerToken(key))
+			}
+			if defs := mappingValue(node, "$defs"); defs != nil && defs.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(defs.Content); i += 2 {
+					name := uniqueMappingKey(schemas, defs.Content[i].Value)
+					setMappingValue(schemas, name, defs.Content[i+1])
+					from := "#" + pointer + "/$defs/" + escapePointerToken(defs.Content[i].Value)
+					moved[from] = "#/components/schemas/" + escapePointerToken(name)
+				}
+				deleteMappingKey(node, "$defs")
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				walk(item, pointer+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+	walk(root, "")
+
+	if len(moved) > 0 {
+		rewriteRefs(root, moved)
+	}
+}
+
+// rewriteRefs replaces references to moved locations, including references
+// pointing inside them. The longest moved location containing a reference
+// wins, so references to nested $defs follow the inner definition.
+func rewriteRefs(node *yaml.Node, moved map[string]string) {
+	froms := make([]string, 0, len(moved))
+	for from := range moved {
+		froms = append(froms, from)
+	}
+	sort.Slice(froms, func(i, j int) bool {
+		if len(froms[i]) != len(froms[j]) {
+			return len(froms[i]) > len(froms[j])
+		}
+		return froms[i] < froms[j]
+	})
+
+	var rewrite func(node *yaml.Node)
+	rewrite = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				value := node.Content[i+1]
+				if node.Content[i].Value == "$ref" && value.Kind == yaml.ScalarNode {
+					for _, from := range froms {
+						if value.Value == from || strings.HasPrefix(value.Value, from+"/") {
+							value.Value = moved[from] + strings.TrimPrefix(value.Value, from)
+							break
+						}
+					}
+					continue
+				}
+				rewrite(value)
+			}
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				rewrite(item)
+			}
+		}
+	}
+	rewrite(node)
+}
+
+// hoistWebhookPayloads replaces the top-level webhooks section, which has no
+// 3.0 equivalent, by component schemas for the webhook request bodies. A
+// webhook named newPet yields a NewPetWebhook model.
+func hoistWebhookPayloads(root, schemas *yaml.Node) {
+	webhooks := mappingValue(root, "webhooks")
+	if webhooks == nil {
+		return
+	}
+	deleteMappingKey(root, "webhooks")
+	if webhooks.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(webhooks.Content); i += 2 {
+		name, pathItem := webhooks.Content[i].Value, webhooks.Content[i+1]
+		for _, method := range []string{"get", "post", "put", "delete", "patch", "head", "options"} {
+			op := mappingValue(pathItem, method)
+			if op == nil {
+				continue
+			}
+			body := resolveLocalRef(root, mappingValue(op, "requestBody"))
+			content := mappingValue(body, "content")
+			if content == nil || content.Kind != yaml.MappingNode || len(content.Content) < 2 {
+				continue
+			}
+			schema := mappingValue(content.Content[1], "schema")
+			if schema == nil || mappingValue(schema, "$ref") != nil {
+				// Referenced payloads are generated as components already
+				continue
+			}
+			setMappingValue(schemas, uniqueMappingKey(schemas, toPascalCase(name)+"Webhook"), schema)
+		}
+	}
+}
+
+// resolveLocalRef follows a local $ref, returning node itself if it is not a reference
+func resolveLocalRef(root, node *yaml.Node) *yaml.Node {
+	ref := mappingValue(node, "$ref")
+	if ref == nil || !strings.HasPrefix(ref.Value, "#/") {
+		return node
+	}
+	target := root
+	for _, token := range strings.Split(strings.TrimPrefix(ref.Value, "#/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		if target = mappingValue(target, token); target == nil {
+			return nil
+		}
+	}
+	return target
+}
+
+// convertSchemasIn converts every schema found under node
+func convertSchemasIn(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch {
+			case key == "schema":
+				convertSchema(value)
+			case key == "schemas":
+				forEachMappingValue(value, convertSchema)
+			case key == "example" || key == "examples" || strings.HasPrefix(key, "x-"):
+				// Example data and extensions are not part of the document structure
+			default:
+				convertSchemasIn(value)
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			convertSchemasIn(item)
+		}
+	}
+}
+
+// convertSchema rewrites the 3.1 keywords of a schema, and of its subschemas,
+// into their 3.0 equivalents
+func convertSchema(schema *yaml.Node) {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+
+	// Subschemas first, so that null members of oneOf/anyOf are recognizable
+	forEachMappingValue(mappingValue(schema, "properties"), convertSchema)
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		convertSchema(mappingValue(schema, key))
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf", "prefixItems"} {
+		if members := mappingValue(schema, key); members != nil && members.Kind == yaml.SequenceNode {
+			for _, member := range members.Content {
+				convertSchema(member)
+			}
+		}
+	}
+
+	// type: [string, "null"] becomes type: string with nullable: true
+	if typ := mappingValue(schema, "type"); typ != nil {
+		var types []string
+		if typ.Kind == yaml.SequenceNode {
+			for _, t := range typ.Content {
+				types = append(types, t.Value)
+			}
+		} else {
+			types = []string{typ.Value}
+		}
+
+		var nonNull []string
+		for _, t := range types {
+			if t == "null" {
+				setMappingValue(schema, "nullable", boolNode(true))
+			} else {
+				nonNull = append(nonNull, t)
+			}
+		}
+		if len(nonNull) == 1 {
+			setMappingValue(schema, "type", stringNode(nonNull[0]))
+		} else {
+			// Mixed types have no 3.0 equivalent and are left untyped
+			deleteMappingKey(schema, "type")
+		}
+	}
+
+	// const becomes a single-value enum
+	if value := mappingValue(schema, "const"); value != nil {
+		if mappingValue(schema, "enum") == nil {
+			setMappingValue(schema, "enum", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{value}})
+		}
+		deleteMappingKey(schema, "const")
+	}
+
+	// examples becomes example, keeping the first one
+	if examples := mappingValue(schema, "examples"); examples != nil && examples.Kind == yaml.SequenceNode {
+		if len(examples.Content) > 0 && mappingValue(schema, "example") == nil {
+			setMappingValue(schema, "example", examples.Content[0])
+		}
+		deleteMappingKey(schema, "examples")
+	}
+
+	// Numeric exclusive bounds become a bound plus a boolean flag
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		if value := mappingValue(schema, bound[0]); value != nil && value.Tag != "!!bool" {
+			setMappingValue(schema, bound[1], value)
+			setMappingValue(schema, bound[0], boolNode(true))
+		}
+	}
+
+	// prefixItems only carries over when every position has the same schema
+	if prefixItems := mappingValue(schema, "prefixItems"); prefixItems != nil {
+		if items := mappingValue(schema, "items"); items == nil || items.Tag == "!!bool" {
+			deleteMappingKey(schema, "items")
+			if same := sameSchema(prefixItems.Content); same != nil {
+				setMappingValue(schema, "items", same)
+			}
+		}
+		deleteMappingKey(schema, "prefixItems")
+	}
+	if items := mappingValue(schema, "items"); items != nil && items.Tag == "!!bool" {
+		deleteMappingKey(schema, "items")
+	}
+	// 3.0 arrays need items, which are untyped when positions differ
+	if typ := mappingValue(schema, "type"); typ != nil && typ.Value == "array" && mappingValue(schema, "items") == nil {
+		setMappingValue(schema, "items", &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+	}
+
+	// A null member of oneOf/anyOf makes the schema nullable
+	for _, key := range []string{"oneOf", "anyOf"} {
+		members := mappingValue(schema, key)
+		if members == nil || members.Kind != yaml.SequenceNode {
+			continue
+		}
+		var kept []*yaml.Node
+		for _, member := range members.Content {
+			if isNullSchema(member) {
+				setMappingValue(schema, "nullable", boolNode(true))
+			} else {
+				kept = append(kept, member)
+			}
+		}
+		members.Content = kept
+		if len(kept) == 1 {
+			deleteMappingKey(schema, key)
+			setMappingValue(schema, "allOf", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: kept})
+		}
+	}
+
+	// Binary content is described with formats in 3.0
+	if encoding := mappingValue(schema, "contentEncoding"); encoding != nil {
+		if encoding.Value == "base64" && mappingValue(schema, "format") == nil {
+			setMappingValue(schema, "format", stringNode("byte"))
+		}
+		deleteMappingKey(schema, "contentEncoding")
+	}
+	if mediaType := mappingValue(schema, "contentMediaType"); mediaType != nil {
+		if mediaType.Value == "application/octet-stream" && mappingValue(schema, "format") == nil {
+			setMappingValue(schema, "format", stringNode("binary"))
+		}
+		deleteMappingKey(schema, "contentMediaType")
+	}
+
+	for _, key := range unsupportedSchemaKeywords {
+		deleteMappingKey(schema, key)
+	}
+}
+
+// isNullSchema reports whether a converted schema only allows null
+func isNullSchema(schema *yaml.Node) bool {
+	if schema.Kind != yaml.MappingNode || len(schema.Content) != 2 {
+		return false
+	}
+	nullable := mappingValue(schema, "nullable")
+	return nullable != nil && nullable.Value == "true"
+}
+
+// sameSchema returns the schema shared by all nodes, or nil if they differ
+func sameSchema(nodes []*yaml.Node) *yaml.Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	first, err := yaml.Marshal(nodes[0])
+	if err != nil {
+		return nil
+	}
+	for _, node := range nodes[1:] {
+		other, err := yaml.Marshal(node)
+		if err != nil || string(other) != string(first) {
+			return nil
+		}
+	}
+	return nodes[0]
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets key in a mapping node, appending it if missing
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, stringNode(key), value)
+}
+
+// deleteMappingKey removes key from a mapping node
+func deleteMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// forEachMappingValue calls fn with every value of a mapping node
+func forEachMappingValue(node *yaml.Node, fn func(*yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(node.Content); i += 2 {
+		fn(node.Content[i])
+	}
+}
+
+// uniqueMappingKey returns key, or key followed by a counter, unused in node
+func uniqueMappingKey(node *yaml.Node, key string) string {
+	name := key
+	for counter := 2; mappingValue(node, name) != nil; counter++ {
+		name = fmt.Sprintf("%s%d", key, counter)
+	}
+	return name
+}
+
+// escapePointerToken escapes a JSON pointer token
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func boolNode(value bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}
+}
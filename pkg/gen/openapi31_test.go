@@ -0,0 +1,217 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestConvertSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "nullable type array",
+			schema: `{type: [string, "null"]}`,
+			want:   "type: string\nnullable: true\n",
+		},
+		{
+			name:   "mixed types",
+			schema: `{type: [string, integer]}`,
+			want:   "{}\n",
+		},
+		{
+			name:   "const",
+			schema: `{const: dog}`,
+			want:   "enum:\n    - dog\n",
+		},
+		{
+			name:   "examples",
+			schema: `{type: string, examples: [a, b]}`,
+			want:   "type: string\nexample: a\n",
+		},
+		{
+			name:   "numeric exclusive bounds",
+			schema: `{type: integer, exclusiveMinimum: 0, exclusiveMaximum: 10}`,
+			want:   "type: integer\nexclusiveMinimum: true\nexclusiveMaximum: true\nminimum: 0\nmaximum: 10\n",
+		},
+		{
+			name:   "uniform prefixItems",
+			schema: `{type: array, prefixItems: [{type: number}, {type: number}], items: false}`,
+			want:   "type: array\nitems:\n    type: number\n",
+		},
+		{
+			name:   "mixed prefixItems",
+			schema: `{type: array, prefixItems: [{type: number}, {type: string}]}`,
+			want:   "type: array\nitems: {}\n",
+		},
+		{
+			name:   "oneOf with null",
+			schema: `{oneOf: [{$ref: '#/components/schemas/Owner'}, {type: "null"}]}`,
+			want:   "nullable: true\nallOf:\n    - $ref: '#/components/schemas/Owner'\n",
+		},
+		{
+			name:   "nested properties",
+			schema: `{type: object, properties: {tag: {type: [string, "null"], $comment: note}}}`,
+			want:   "type: object\nproperties:\n    tag:\n        type: string\n        nullable: true\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.schema), &node); err != nil {
+				t.Fatal(err)
+			}
+			schema := node.Content[0]
+			convertSchema(schema)
+			normalizeStyle(schema)
+
+			got, err := yaml.Marshal(schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("convertSchema() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+const openAPI31Spec = `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+  summary: Pets API
+  license:
+    name: MIT
+    identifier: MIT
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                pet:
+                  $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: [string, 'null']
+        owner:
+          $ref: '#/components/schemas/Pet/$defs/Owner'
+      $defs:
+        Owner:
+          type: object
+          properties:
+            name:
+              type: string
+            location:
+              type: array
+              prefixItems:
+                - type: string
+                - type: number
+`
+
+func TestGenerateFromOpenAPI31(t *testing.T) {
+	files := generateFromSpec(t, openAPI31Spec, &Config{GenerateModels: true, GenerateClient: true})
+	models := files["models.go"]
+
+	for _, want := range []string{
+		"Tag   *string `json:\"tag,omitempty\"`",
+		"Owner *Owner  `json:\"owner,omitempty\"`",
+		"type Owner struct {",
+		// Tuples of different types are untyped
+		"Location []interface{} `json:\"location,omitempty\"`",
+		"type NewPetWebhook struct {",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("models.go missing %q", want)
+		}
+	}
+}
+
+func TestHoistNestedDefs(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        outer:
+          $ref: '#/components/schemas/Pet/$defs/Outer'
+        inner:
+          $ref: '#/components/schemas/Pet/$defs/Outer/$defs/Inner'
+      $defs:
+        Outer:
+          type: object
+          properties:
+            inner:
+              $ref: '#/components/schemas/Pet/$defs/Outer/$defs/Inner'
+          $defs:
+            Inner:
+              type: string
+`
+	// References used to be matched in map order, so repeat the conversion
+	for i := 0; i < 50; i++ {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(spec), &node); err != nil {
+			t.Fatal(err)
+		}
+		if !downgradeOpenAPI31(&node) {
+			t.Fatal("downgradeOpenAPI31() did not convert the document")
+		}
+		out, err := yaml.Marshal(&node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(out), "$defs") {
+			t.Fatalf("converted document still references $defs:\n%s", out)
+		}
+		if got := strings.Count(string(out), "$ref: '#/components/schemas/Inner'"); got != 2 {
+			t.Fatalf("converted document has %d references to Inner, want 2:\n%s", got, out)
+		}
+	}
+}
+
+func TestDowngradeOpenAPI31IgnoresOlderVersions(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("openapi: 3.0.3\npaths: {}\n"), &node); err != nil {
+		t.Fatal(err)
+	}
+	if downgradeOpenAPI31(&node) {
+		t.Error("downgradeOpenAPI31() converted a 3.0 document")
+	}
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// reservedParamNames are the identifiers generated methods declare or use
+// themselves, which parameter arguments must not shadow
+var reservedParamNames = map[string]bool{
+	"c": true, "ctx": true, "req": true, "params": true,
+	"path": true, "opts": true, "options": true, "resp": true, "err": true, "result": true,
+	"requester": true, "ok": true, "stream": true,
+	"client": true, "context": true, "errors": true, "fmt": true, "strings": true, "time": true,
+}
+
+// mergeParameters combines the parameters declared on a path with those of one
+// of its operations. Operation parameters override path parameters with the
+// same name and location.
+func mergeParameters(pathParams, opParams openapi3.Parameters) openapi3.Parameters {
+	merged := make(openapi3.Parameters, 0, len(pathParams)+len(opParams))
+	index := make(map[string]int)
+	for _, params := range []openapi3.Parameters{pathParams, opParams} {
+		for _, paramRef := range params {
+			if paramRef == nil || paramRef.Value == nil {
+				continue
+			}
+			key := paramRef.Value.In + ":" + paramRef.Value.Name
+			if i, ok := index[key]; ok {
+				merged[i] = paramRef
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, paramRef)
+		}
+	}
+	return merged
+}
+
+// assignParamNames gives every parameter a Go argument name and a Params
+// struct field name. Names are valid, non-keyword identifiers that don't
+// shadow anything the generated method uses, and are unique per operation.
+func assignParamNames(params []Parameter) {
+	varNames := make(map[string]bool)
+	fieldNames := make(map[string]bool)
+	for i := range params {
+		params[i].VarName = uniqueName(paramVarName(params[i].Name), varNames)
+		params[i].FieldName = uniqueName(paramFieldName(params[i].Name), fieldNames)
+	}
+}
+
+// paramVarName converts a parameter name into a Go argument name. Names that
+// already are identifiers are kept as they are.
+func paramVarName(name string) string {
+	varName := name
+	if !token.IsIdentifier(name) {
+		varName = toCamelCase(name)
+	}
+	switch {
+	case varName == "":
+		return "param"
+	case unicode.IsDigit(rune(varName[0])):
+		return "param" + varName
+	case token.IsKeyword(varName) || types.Universe.Lookup(varName) != nil || reservedParamNames[varName]:
+		return varName + "Param"
+	}
+	return varName
+}
+
+// paramFieldName converts a parameter name into an exported field name
+func paramFieldName(name string) string {
+	fieldName := toPascalCase(name)
+	if fieldName == "" || unicode.IsDigit(rune(fieldName[0])) {
+		return "Param" + fieldName
+	}
+	return fieldName
+}
+
+// uniqueName returns name, or name with the lowest numeric suffix that is
+// not yet taken, and records it as taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for counter := 2; taken[unique]; counter++ {
+		unique = fmt.Sprintf("%s%d", name, counter)
+	}
+	taken[unique] = true
+	return unique
+}
@@ -0,0 +1,66 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestAssignParamNames(t *testing.T) {
+	params := []Parameter{
+		{Name: "id", In: "path"},
+		{Name: "user-id", In: "path"},
+		{Name: "type", In: "query"},
+		{Name: "opts", In: "query"},
+		{Name: "string", In: "query"},
+		{Name: "1st", In: "query"},
+		{Name: "$", In: "query"},
+		{Name: "X-Rate", In: "header"},
+		{Name: "x_rate", In: "query"},
+	}
+	assignParamNames(params)
+
+	want := []struct{ varName, fieldName string }{
+		{"id", "ID"},
+		{"userID", "UserID"},
+		{"typeParam", "Type"},
+		{"optsParam", "Opts"},
+		{"stringParam", "String"},
+		{"param1St", "Param1St"},
+		{"param", "Param"},
+		{"xRate", "XRate"},
+		{"x_rate", "XRate2"},
+	}
+	for i, w := range want {
+		if params[i].VarName != w.varName || params[i].FieldName != w.fieldName {
+			t.Errorf("%s: names = %s, %s; want %s, %s",
+				params[i].Name, params[i].VarName, params[i].FieldName, w.varName, w.fieldName)
+		}
+	}
+}
+
+func TestMergeParameters(t *testing.T) {
+	param := func(name, in, description string) *openapi3.ParameterRef {
+		return &openapi3.ParameterRef{Value: &openapi3.Parameter{Name: name, In: in, Description: description}}
+	}
+	pathParams := openapi3.Parameters{
+		param("id", "path", "path id"),
+		param("X-Tenant", "header", "path tenant"),
+	}
+	opParams := openapi3.Parameters{
+		param("limit", "query", "op limit"),
+		param("X-Tenant", "header", "op tenant"),
+		param("id", "query", "op id"),
+	}
+
+	merged := mergeParameters(pathParams, opParams)
+	want := []string{"path id", "op tenant", "op limit", "op id"}
+	if len(merged) != len(want) {
+		t.Fatalf("mergeParameters() returned %d parameters, want %d", len(merged), len(want))
+	}
+	for i, description := range want {
+		if merged[i].Value.Description != description {
+			t.Errorf("mergeParameters()[%d] = %q, want %q", i, merged[i].Value.Description, description)
+		}
+	}
+}
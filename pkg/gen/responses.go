@@ -0,0 +1,119 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// requestSetup is the data of the template block preparing an operation's
+// request, which is shared by its buffered and streaming methods
+type requestSetup struct {
+	Operation
+	// Stream is set when the request is sent through RequestStream
+	Stream bool
+}
+
+// rawSuccessMediaType returns the media type of an operation's single success
+// response when it is returned as a string or stream
+func rawSuccessMediaType(op Operation) string {
+	if op.HasMultipleSuccessResponses || op.SuccessResponse == nil || op.SuccessResponse.Type != "" {
+		return ""
+	}
+	return op.SuccessResponse.MediaType
+}
+
+// isStreamResponse reports whether an operation returns its response body as
+// a *client.StreamResponse
+func isStreamResponse(op Operation) bool {
+	mediaType := rawSuccessMediaType(op)
+	return mediaType != "" && !isTextMediaType(mediaType) && !isEventStreamMediaType(mediaType) && !isNDJSONMediaType(mediaType)
+}
+
+// isTextResponse reports whether an operation returns its response body as a string
+func isTextResponse(op Operation) bool {
+	return isTextMediaType(rawSuccessMediaType(op))
+}
+
+// isRecordStreamResponse reports whether an operation returns its response
+// as an iterator over newline-delimited JSON records
+func isRecordStreamResponse(op Operation) bool {
+	return isNDJSONMediaType(rawSuccessMediaType(op))
+}
+
+// requestBodyArg returns the body argument an operation's methods send: the
+// request, the request's records streamed as NDJSON, or nil without a body
+func requestBodyArg(op Operation) string {
+	switch {
+	case op.RequestBody == nil:
+		return "nil"
+	case isNDJSONMediaType(op.RequestBody.MediaType):
+		return "client.NDJSONBody(req)"
+	}
+	return "req"
+}
+
+// isEventStreamResponse reports whether an operation returns its response
+// as an iterator over server-sent events
+func isEventStreamResponse(op Operation) bool {
+	return isEventStreamMediaType(rawSuccessMediaType(op))
+}
+
+// requestContentType returns the content type set by the request setup
+// block: media types other than application/json that the client encodes by,
+// and those RequestStream encodes multipart and form bodies by
+func requestContentType(setup requestSetup) string {
+	rb := setup.RequestBody
+	if rb == nil || rb.MediaType == "application/json" {
+		return ""
+	}
+	if !setup.Stream && (rb.MediaType == multipartMediaType || rb.MediaType == formMediaType) {
+		return ""
+	}
+	return rb.MediaType
+}
+
+// acceptOption returns the request option accepting the media types of an
+// operation's responses, or "" when they are only application/json, which
+// is accepted by default
+func acceptOption(op Operation) string {
+	statusCodes := make([]string, 0, len(op.Responses))
+	for statusCode := range op.Responses {
+		statusCodes = append(statusCodes, statusCode)
+	}
+	sort.Strings(statusCodes)
+
+	var mediaTypes []string
+	seen := make(map[string]bool)
+	for _, statusCode := range statusCodes {
+		for _, mediaType := range op.Responses[statusCode].MediaTypes {
+			if seen[mediaType] {
+				continue
+			}
+			seen[mediaType] = true
+			mediaTypes = append(mediaTypes, fmt.Sprintf("%q", mediaType))
+		}
+	}
+	if len(mediaTypes) == 0 || (len(mediaTypes) == 1 && mediaTypes[0] == `"application/json"`) {
+		return ""
+	}
+	return fmt.Sprintf("client.WithAccept(%s)", strings.Join(mediaTypes, ", "))
+}
+
+// setupRequest returns the data of the request setup block of an operation
+func setupRequest(op Operation, stream bool) requestSetup {
+	return requestSetup{Operation: op, Stream: stream}
+}
+
+// hasRequestOptions reports whether the request setup block declares options
+func hasRequestOptions(setup requestSetup) bool {
+	op := setup.Operation
+	return op.Server != nil ||
+		securityOption(op) != "" ||
+		acceptOption(op) != "" ||
+		requestContentType(setup) != "" ||
+		(op.RequestBody != nil && len(op.RequestBody.Encodings) > 0) ||
+		hasQueryParams(op.Parameters) ||
+		hasHeaderParams(op.Parameters) ||
+		hasCookieParams(op.Parameters)
+}
@@ -0,0 +1,382 @@
+package gen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateBinaryAndTextResponses(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Export API
+  version: 1.0.0
+paths:
+  /exports/{id}:
+    get:
+      operationId: downloadExport
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The export
+          content:
+            application/octet-stream:
+              schema:
+                type: string
+                format: binary
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /thumbnails:
+    post:
+      operationId: makeThumbnail
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                image:
+                  type: string
+                  format: binary
+      responses:
+        '200':
+          description: Thumbnail
+          content:
+            image/png:
+              schema:
+                type: string
+                format: binary
+  /version:
+    get:
+      operationId: getVersion
+      responses:
+        '200':
+          description: Version
+          content:
+            text/plain:
+              schema:
+                type: string
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"DownloadExport(ctx context.Context, id string) (*client.StreamResponse, error)",
+		"requester, ok := c.Client.(client.StreamRequester)\n\tif !ok {\n\t\treturn nil, client.ErrNotSupported\n\t}",
+		`requester.RequestStream(ctx, "GET", path, nil, opts...)`,
+		"return nil, decodeDownloadExportError(err)",
+		`client.WithAccept("application/octet-stream", "application/json")`,
+		"200: The export (application/octet-stream)",
+		// Raw methods read streamed responses into memory
+		"// Use DownloadExportRaw to read the whole response into memory instead.\nfunc",
+		"// read into memory. Use DownloadExport to stream it instead.",
+		"MakeThumbnail(ctx context.Context, req MakeThumbnailRequest) (*client.StreamResponse, error)",
+		`client.WithContentType("multipart/form-data")`,
+		`requester.RequestStream(ctx, "POST", path, req, opts...)`,
+		`requester.RequestMultipart(ctx, "POST", path, req, opts...)`,
+		"GetVersion(ctx context.Context) (string, error)",
+		"return string(resp.Body), nil",
+		`client.WithAccept("text/plain")`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+
+	if strings.Contains(clientStr, "Use DownloadExportRaw to access the undecoded response") {
+		t.Error("DownloadExport should not point at DownloadExportRaw for the undecoded response")
+	}
+
+	// The content type option only applies to the streaming method
+	if strings.Count(clientStr, `client.WithContentType("multipart/form-data")`) != 1 {
+		t.Error("only MakeThumbnail should set the multipart content type")
+	}
+}
+
+func TestGenerateEventStreamResponses(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Chat API
+  version: 1.0.0
+paths:
+  /chat:
+    post:
+      operationId: streamChat
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                prompt:
+                  type: string
+      responses:
+        '200':
+          description: Chat chunks
+          content:
+            text/event-stream:
+              schema:
+                type: object
+                properties:
+                  text:
+                    type: string
+        '429':
+          description: Rate limited
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /events:
+    get:
+      operationId: watchEvents
+      responses:
+        '200':
+          description: Raw events
+          content:
+            text/event-stream: {}
+  /jobs/{id}:
+    get:
+      operationId: getJob
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The job, or its progress
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+            text/event-stream:
+              schema:
+                type: string
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	modelsStr := files["models.go"]
+	clientStr := files["client.go"]
+
+	if !strings.Contains(modelsStr, "type StreamChatEvent struct") {
+		t.Error("models.go should contain the inline event schema as StreamChatEvent")
+	}
+
+	expected := []string{
+		`"iter"`,
+		"StreamChat(ctx context.Context, req StreamChatRequest, options ...client.RequestOption) iter.Seq2[StreamChatEvent, error]",
+		"return c.StreamChatRaw(ctx, req, options...)",
+		"return client.Events[StreamChatEvent](func() (*client.EventStream, error) {",
+		"StreamChatRaw(ctx context.Context, req StreamChatRequest, options ...client.RequestOption) (*client.EventStream, error)",
+		"opts = append(opts, options...)",
+		"requester, ok := c.Client.(client.EventRequester)",
+		`requester.RequestEvents(ctx, "POST", path, req, opts...)`,
+		"return nil, decodeStreamChatError(err)",
+		`client.WithAccept("text/event-stream", "application/json")`,
+		"WatchEvents(ctx context.Context, options ...client.RequestOption) iter.Seq2[client.Event, error]",
+		"GetJob(ctx context.Context, id string) (*Error, error)",
+		"GetJobEvents(ctx context.Context, id string, options ...client.RequestOption) iter.Seq2[string, error]",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGeneratedEventStreamReconnects(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs the generated client")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	// The generated package lives in the module to import pkg/client; the
+	// underscore keeps ./... patterns from picking it up
+	dir, err := os.MkdirTemp(".", "_events_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Jobs API
+  version: 1.0.0
+paths:
+  /jobs:
+    get:
+      operationId: watchJobs
+      responses:
+        '200':
+          description: Job updates
+          content:
+            text/event-stream:
+              schema:
+                type: string
+`
+	generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true, OutputDir: dir, PackageName: "jobs"})
+
+	runtimeTest := `package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jmcarbo/oapix/pkg/client"
+)
+
+func TestWatchJobsReconnects(t *testing.T) {
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		if len(lastEventIDs) > 2 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "retry: 1\nid: %d\ndata: update %d\n\n", len(lastEventIDs), len(lastEventIDs))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&client.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var updates []string
+	for update, err := range c.WatchJobs(context.Background(), client.WithReconnect(1)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		updates = append(updates, update)
+	}
+
+	if fmt.Sprint(updates) != "[update 1 update 2]" {
+		t.Errorf("updates = %v", updates)
+	}
+	if fmt.Sprint(lastEventIDs) != "[ 1 2]" {
+		t.Errorf("Last-Event-ID headers = %q", lastEventIDs)
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "reconnect_test.go"), []byte(runtimeTest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(goTool, "test", "./"+filepath.Base(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated client test failed: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateNDJSONStreams(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Bulk API
+  version: 1.0.0
+paths:
+  /users/export:
+    get:
+      operationId: exportUsers
+      responses:
+        '200':
+          description: One user per line
+          content:
+            application/x-ndjson:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/import:
+    post:
+      operationId: importUsers
+      requestBody:
+        content:
+          application/x-ndjson:
+            schema:
+              type: array
+              items:
+                $ref: '#/components/schemas/User'
+      responses:
+        '200':
+          description: Import summary
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /logs:
+    get:
+      operationId: getLogs
+      responses:
+        '200':
+          description: Logs
+          content:
+            application/jsonl: {}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		`"iter"`,
+		`"encoding/json"`,
+		"ExportUsers(ctx context.Context) iter.Seq2[User, error]",
+		"return client.Records[User](func() (*client.StreamResponse, error) {",
+		"ExportUsersRaw(ctx context.Context) (*client.StreamResponse, error)",
+		`requester.RequestStream(ctx, "GET", path, nil, opts...)`,
+		`client.WithAccept("application/x-ndjson")`,
+		"ImportUsers(ctx context.Context, req iter.Seq[User]) (*User, error)",
+		`client.WithContentType("application/x-ndjson")`,
+		`c.Request(ctx, "POST", path, client.NDJSONBody(req), opts...)`,
+		"GetLogs(ctx context.Context) iter.Seq2[json.RawMessage, error]",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
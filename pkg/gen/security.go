@@ -0,0 +1,196 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SecurityScheme is a security scheme declared in the components of the spec
+type SecurityScheme struct {
+	Name        string
+	Type        string
+	Scheme      string // HTTP authorization scheme, such as basic or bearer
+	In          string // where an API key is sent: header, query or cookie
+	ParamName   string // name of the header, query parameter or cookie of an API key
+	Description string
+	// Setter is the name of the client method setting the scheme's
+	// credential, empty for schemes without one such as mutualTLS
+	Setter       string
+	SetterParams string
+	SetterDoc    string
+	// Credential is the client.Credential literal built from SetterParams
+	Credential string
+}
+
+// clientMethods are the methods of client.Client, which setters can't shadow
+var clientMethods = []string{
+	"Request", "RequestJSON", "RequestForm",
+	"BaseURL", "SetBaseURL",
+}
+
+// extractSecuritySchemes returns the security schemes of the spec sorted by
+// name, with setters named apart from the methods of operations
+func (g *Generator) extractSecuritySchemes(operations []Operation) []SecurityScheme {
+	if g.spec.Components == nil || len(g.spec.Components.SecuritySchemes) == 0 {
+		return nil
+	}
+
+	used := make(map[string]bool)
+	for _, name := range clientMethods {
+		used[name] = true
+	}
+	for _, op := range operations {
+		used[op.MethodName+op.Variant] = true
+		used[op.MethodName+op.Variant+"Raw"] = true
+	}
+
+	names := make([]string, 0, len(g.spec.Components.SecuritySchemes))
+	for name, ref := range g.spec.Components.SecuritySchemes {
+		if ref != nil && ref.Value != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	schemes := make([]SecurityScheme, 0, len(names))
+	for _, name := range names {
+		s := g.spec.Components.SecuritySchemes[name].Value
+		scheme := SecurityScheme{
+			Name:        name,
+			Type:        s.Type,
+			Scheme:      s.Scheme,
+			In:          s.In,
+			ParamName:   s.Name,
+			Description: s.Description,
+		}
+		if setCredential(&scheme) {
+			setter := "Set" + toPascalCase(name)
+			if used[setter] {
+				setter += "Credential"
+			}
+			base := setter
+			for counter := 2; used[setter]; counter++ {
+				setter = fmt.Sprintf("%s%d", base, counter)
+			}
+			used[setter] = true
+			scheme.Setter = setter
+		}
+		schemes = append(schemes, scheme)
+	}
+	return schemes
+}
+
+// setCredential fills in the setter parameters, documentation and credential
+// of a scheme, reporting whether the scheme takes a credential
+func setCredential(s *SecurityScheme) bool {
+	switch s.Type {
+	case "apiKey":
+		location := map[string]string{"query": "query parameter", "cookie": "cookie"}[s.In]
+		if location == "" {
+			location = "header"
+		}
+		s.SetterParams = "apiKey string"
+		s.SetterDoc = fmt.Sprintf("sets the API key sent in the %s %s", s.ParamName, location)
+		s.Credential = "client.Credential{Value: apiKey}"
+	case "http":
+		switch strings.ToLower(s.Scheme) {
+		case "basic":
+			s.SetterParams = "username, password string"
+			s.SetterDoc = "sets the username and password sent with HTTP basic authentication"
+			s.Credential = "client.Credential{Username: username, Password: password}"
+		case "bearer":
+			s.SetterParams = "token string"
+			s.SetterDoc = "sets the bearer token sent"
+			s.Credential = "client.Credential{Value: token}"
+		default:
+			s.SetterParams = "token string"
+			s.SetterDoc = fmt.Sprintf("sets the credentials sent with the %s authorization scheme", s.Scheme)
+			s.Credential = "client.Credential{Value: token}"
+		}
+	case "oauth2":
+		s.SetterParams = "tokenSource client.OAuth2TokenSource"
+		s.SetterDoc = "sets the source of the OAuth2 access tokens sent"
+		s.Credential = "client.Credential{TokenSource: tokenSource}"
+	case "openIdConnect":
+		s.SetterParams = "tokenSource client.OAuth2TokenSource"
+		s.SetterDoc = "sets the source of the OpenID Connect tokens sent"
+		s.Credential = "client.Credential{TokenSource: tokenSource}"
+	default:
+		// Client certificates of mutualTLS schemes are set on the HTTP client
+		return false
+	}
+	return true
+}
+
+// securitySchemeLiteral returns the client.SecurityScheme literal describing a scheme
+func securitySchemeLiteral(s SecurityScheme) string {
+	types := map[string]string{
+		"apiKey":        "client.SecurityAPIKey",
+		"http":          "client.SecurityHTTP",
+		"oauth2":        "client.SecurityOAuth2",
+		"openIdConnect": "client.SecurityOpenIDConnect",
+		"mutualTLS":     "client.SecurityMutualTLS",
+	}
+	typ, ok := types[s.Type]
+	if !ok {
+		typ = strconv.Quote(s.Type)
+	}
+
+	fields := []string{"Type: " + typ}
+	if s.Scheme != "" {
+		fields = append(fields, fmt.Sprintf("Scheme: %q", s.Scheme))
+	}
+	if s.In != "" {
+		fields = append(fields, fmt.Sprintf("In: %q", s.In))
+	}
+	if s.ParamName != "" {
+		fields = append(fields, fmt.Sprintf("Name: %q", s.ParamName))
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// operationSecurity returns the security requirements of an operation, which
+// override those of the spec, with the scheme names of each sorted. It
+// returns nil when the spec declares no security schemes, and an empty list
+// for operations that need no credentials.
+func (g *Generator) operationSecurity(op *openapi3.Operation) [][]string {
+	if g.spec.Components == nil || len(g.spec.Components.SecuritySchemes) == 0 {
+		return nil
+	}
+
+	requirements := g.spec.Security
+	if op.Security != nil {
+		requirements = *op.Security
+	}
+	security := make([][]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		security = append(security, names)
+	}
+	return security
+}
+
+// securityOption returns the client.WithSecurity option of an operation, or
+// "" when it has no security requirements to apply
+func securityOption(op Operation) string {
+	if op.Security == nil {
+		return ""
+	}
+	requirements := make([]string, len(op.Security))
+	for i, names := range op.Security {
+		quoted := make([]string, len(names))
+		for j, name := range names {
+			quoted[j] = strconv.Quote(name)
+		}
+		requirements[i] = "client.SecurityRequirement{" + strings.Join(quoted, ", ") + "}"
+	}
+	return "client.WithSecurity(" + strings.Join(requirements, ", ") + ")"
+}
@@ -0,0 +1,126 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateSecurity(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Secure API
+  version: 1.0.0
+security:
+  - api_key: []
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    session:
+      type: apiKey
+      in: cookie
+      name: SESSION
+    basic:
+      type: http
+      scheme: basic
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://auth.example.com/token
+          scopes:
+            read: Read access
+paths:
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        '204':
+          description: OK
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+    post:
+      operationId: createPet
+      security:
+        - basic: []
+        - oauth: [read]
+          session: []
+      responses:
+        '204':
+          description: OK
+  /me:
+    get:
+      operationId: me
+      security:
+        - {}
+        - session: []
+      responses:
+        '204':
+          description: OK
+  /basic:
+    get:
+      operationId: setBasic
+      responses:
+        '204':
+          description: OK
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		`"api_key": {Type: client.SecurityAPIKey, In: "header", Name: "X-API-Key"},`,
+		`"basic":   {Type: client.SecurityHTTP, Scheme: "basic"},`,
+		"config.SecuritySchemes = SecuritySchemes",
+		"func (c *Client) SetAPIKey(apiKey string) error {",
+		"setter, ok := c.Client.(client.CredentialSetter)\n\tif !ok {\n\t\treturn client.ErrNotSupported\n\t}",
+		`setter.SetCredential("session", client.Credential{Value: apiKey})`,
+		// Setters are named apart from operation methods
+		"func (c *Client) SetBasicCredential(username, password string) error {",
+		"func (c *Client) SetOauth(tokenSource client.OAuth2TokenSource) error {",
+		// Operations without requirements send no credentials
+		"client.WithSecurity())",
+		`client.WithSecurity(client.SecurityRequirement{"api_key"})`,
+		`client.WithSecurity(client.SecurityRequirement{"basic"}, client.SecurityRequirement{"oauth", "session"})`,
+		`client.WithSecurity(client.SecurityRequirement{}, client.SecurityRequirement{"session"})`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateWithoutSecuritySchemes(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Open API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	// The client's API key is still sent as a bearer token
+	for _, unexpected := range []string{"SecuritySchemes", "WithSecurity"} {
+		if strings.Contains(clientStr, unexpected) {
+			t.Errorf("client.go should not contain %q", unexpected)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Server is a server declared at the top of the spec
+type Server struct {
+	// VarName is the name of the generated client.Server variable
+	VarName string
+	// Name selects the server with client.Config.Server
+	Name        string
+	URL         string
+	Description string
+	Variables   []ServerVariable
+}
+
+// ServerVariable is a variable of a server URL template
+type ServerVariable struct {
+	Name        string
+	Default     string
+	Enum        []string
+	Description string
+}
+
+// extractServers returns the servers of the spec, named after their
+// description or else their index
+func (g *Generator) extractServers() []Server {
+	var servers []Server
+	used := map[string]bool{"Servers": true}
+	for i, s := range g.spec.Servers {
+		if s == nil {
+			continue
+		}
+
+		name := toKebabCase(s.Description)
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		varName := "Server" + toPascalCase(name)
+		base, baseName := varName, name
+		for counter := 2; used[varName]; counter++ {
+			varName = fmt.Sprintf("%s%d", base, counter)
+			name = fmt.Sprintf("%s-%d", baseName, counter)
+		}
+		used[varName] = true
+
+		server := operationServer(s)
+		server.VarName = varName
+		server.Name = name
+		servers = append(servers, *server)
+	}
+	return servers
+}
+
+// sortedServerVariables returns the names of a server's variables in order
+func sortedServerVariables(s *openapi3.Server) []string {
+	names := make([]string, 0, len(s.Variables))
+	for name, v := range s.Variables {
+		if v != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// operationServer converts a server, such as one an operation or its path
+// declares, whose URL template is resolved at request time
+func operationServer(s *openapi3.Server) *Server {
+	server := &Server{URL: s.URL, Description: s.Description}
+	for _, variable := range sortedServerVariables(s) {
+		v := s.Variables[variable]
+		server.Variables = append(server.Variables, ServerVariable{
+			Name:        variable,
+			Default:     v.Default,
+			Enum:        v.Enum,
+			Description: v.Description,
+		})
+	}
+	return server
+}
+
+// serverLiteral returns the client.Server literal declaring a server
+func serverLiteral(s Server) string {
+	var b strings.Builder
+	b.WriteString("client.Server{\n")
+	if s.Name != "" {
+		fmt.Fprintf(&b, "\tName: %q,\n", s.Name)
+	}
+	fmt.Fprintf(&b, "\tURL: %q,\n", s.URL)
+	if s.Description != "" {
+		fmt.Fprintf(&b, "\tDescription: %q,\n", s.Description)
+	}
+	if len(s.Variables) > 0 {
+		b.WriteString("\tVariables: map[string]client.ServerVariable{\n")
+		for _, v := range s.Variables {
+			fmt.Fprintf(&b, "\t\t%q: {Default: %q", v.Name, v.Default)
+			if len(v.Enum) > 0 {
+				enum := make([]string, len(v.Enum))
+				for i, value := range v.Enum {
+					enum[i] = strconv.Quote(value)
+				}
+				fmt.Fprintf(&b, ", Enum: []string{%s}", strings.Join(enum, ", "))
+			}
+			if v.Description != "" {
+				fmt.Fprintf(&b, ", Description: %q", v.Description)
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("\t},\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
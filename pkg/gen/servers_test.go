@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateServers(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Regions API
+  version: 1.0.0
+servers:
+  - url: https://{region}.api.example.com/{version}
+    description: Production
+    variables:
+      region:
+        default: us
+        enum: [us, eu]
+      version:
+        default: v1
+  - url: https://sandbox.example.com
+    description: Sandbox
+  - url: http://localhost:8080
+paths:
+  /uploads:
+    servers:
+      - url: https://{region}.uploads.example.com
+        variables:
+          region:
+            default: eu
+    post:
+      operationId: upload
+      responses:
+        '204':
+          description: Uploaded
+`
+
+	files := generateFromSpec(t, specContent, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	expected := []string{
+		"var Servers = []client.Server{ServerProduction, ServerSandbox, Server2}",
+		`Name:        "production",`,
+		`URL:         "https://{region}.api.example.com/{version}",`,
+		`"region":  {Default: "us", Enum: []string{"us", "eu"}},`,
+		`"version": {Default: "v1"},`,
+		`Name: "2",`,
+		// The caller's config is left untouched
+		"configCopy := *config",
+		"config.Servers = Servers",
+		// Operation servers keep their variables, set at request time
+		"client.WithServer(client.Server{\n\t\tURL: \"https://{region}.uploads.example.com\",",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(clientStr, exp) {
+			t.Errorf("client.go should contain %q", exp)
+		}
+	}
+}
+
+func TestOperationServer(t *testing.T) {
+	server := operationServer(&openapi3.Server{
+		URL: "https://{region}.example.com:{port}/{region}",
+		Variables: map[string]*openapi3.ServerVariable{
+			"region": {Default: "eu", Enum: []string{"eu", "us"}},
+			"port":   {Default: "8443"},
+		},
+	})
+	want := `client.Server{
+	URL: "https://{region}.example.com:{port}/{region}",
+	Variables: map[string]client.ServerVariable{
+		"port": {Default: "8443"},
+		"region": {Default: "eu", Enum: []string{"eu", "us"}},
+	},
+}`
+	if got := serverLiteral(*server); got != want {
+		t.Errorf("serverLiteral() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,246 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// isSwagger2 reports whether a parsed document is a Swagger 2.0 one
+func isSwagger2(doc *yaml.Node) bool {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	version := mappingValue(root, "swagger")
+	return version != nil && strings.HasPrefix(version.Value, "2")
+}
+
+// convertSwagger2 converts a Swagger 2.0 document into the equivalent
+// OpenAPI 3.0 document. Definitions become component schemas,
+// consumes/produces become media types, formData parameters become form
+// request bodies and securityDefinitions become security schemes.
+func convertSwagger2(doc *yaml.Node) (*yaml.Node, error) {
+	// Response codes and other numeric keys must decode as strings for JSON
+	stringifyKeys(doc)
+
+	var raw interface{}
+	if err := doc.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode Swagger document: %w", err)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Swagger document: %w", err)
+	}
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(data, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse Swagger document: %w", err)
+	}
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger document to OpenAPI 3: %w", err)
+	}
+	applyCollectionFormats(&doc2, doc3)
+
+	data, err = json.Marshal(doc3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode converted document: %w", err)
+	}
+	var converted yaml.Node
+	if err := yaml.Unmarshal(data, &converted); err != nil {
+		return nil, fmt.Errorf("failed to parse converted document: %w", err)
+	}
+
+	// The conversion goes through Go maps, so restore the original property order
+	restorePropertyOrder(&converted, propertyOrders(doc))
+
+	return &converted, nil
+}
+
+// applyCollectionFormats sets the style and explode of the array query and
+// formData parameters of the converted document from their collectionFormat,
+// which the conversion drops. tsv has no OpenAPI 3 equivalent and keeps the
+// defaults.
+func applyCollectionFormats(doc2 *openapi2.T, doc3 *openapi3.T) {
+	if doc3.Components != nil {
+		for name, p := range doc2.Parameters {
+			if ref := doc3.Components.Parameters[name]; ref != nil && ref.Value != nil {
+				setCollectionFormat(p, ref.Value)
+			}
+		}
+	}
+	if doc3.Paths == nil {
+		return
+	}
+
+	for path, item2 := range doc2.Paths {
+		item3 := doc3.Paths.Value(path)
+		if item2 == nil || item3 == nil {
+			continue
+		}
+		setParameterCollectionFormats(item2.Parameters, item3.Parameters)
+		for method, op2 := range item2.Operations() {
+			op3 := item3.GetOperation(method)
+			if op3 == nil {
+				continue
+			}
+			setParameterCollectionFormats(op2.Parameters, op3.Parameters)
+			if op3.RequestBody == nil || op3.RequestBody.Value == nil {
+				continue
+			}
+			for _, p := range op2.Parameters {
+				if p.Ref != "" {
+					p = doc2.Parameters[strings.TrimPrefix(p.Ref, "#/parameters/")]
+				}
+				if p == nil || p.In != "formData" {
+					continue
+				}
+				style, explode, ok := collectionStyle(p)
+				if !ok {
+					continue
+				}
+				for _, mediaType := range op3.RequestBody.Value.Content {
+					if mediaType.Encoding == nil {
+						mediaType.Encoding = make(map[string]*openapi3.Encoding)
+					}
+					encoding := mediaType.Encoding[p.Name]
+					if encoding == nil {
+						encoding = &openapi3.Encoding{}
+						mediaType.Encoding[p.Name] = encoding
+					}
+					encoding.Style, encoding.Explode = style, &explode
+				}
+			}
+		}
+	}
+}
+
+// setParameterCollectionFormats sets the style of the converted parameters
+// from the collectionFormat of the Swagger ones. Referenced parameters are
+// set in the components.
+func setParameterCollectionFormats(params2 openapi2.Parameters, params3 openapi3.Parameters) {
+	for _, p2 := range params2 {
+		if p2 == nil || p2.Ref != "" {
+			continue
+		}
+		if p3 := params3.GetByInAndName(p2.In, p2.Name); p3 != nil {
+			setCollectionFormat(p2, p3)
+		}
+	}
+}
+
+// setCollectionFormat sets the style of a converted query parameter from
+// the collectionFormat of the Swagger one
+func setCollectionFormat(p2 *openapi2.Parameter, p3 *openapi3.Parameter) {
+	if p2.In != openapi3.ParameterInQuery {
+		return
+	}
+	if style, explode, ok := collectionStyle(p2); ok {
+		p3.Style, p3.Explode = style, &explode
+	}
+}
+
+// collectionStyle returns the OpenAPI 3 style and explode of an array
+// parameter's collectionFormat, which defaults to csv
+func collectionStyle(p *openapi2.Parameter) (string, bool, bool) {
+	if !p.Type.Is("array") {
+		return "", false, false
+	}
+	switch p.CollectionFormat {
+	case "", "csv":
+		return openapi3.SerializationForm, false, true
+	case "ssv":
+		return openapi3.SerializationSpaceDelimited, false, true
+	case "pipes":
+		return openapi3.SerializationPipeDelimited, false, true
+	case "multi":
+		return openapi3.SerializationForm, true, true
+	}
+	return "", false, false
+}
+
+// stringifyKeys tags every mapping key under node as a string
+func stringifyKeys(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			node.Content[i].Tag = "!!str"
+		}
+	}
+	for _, child := range node.Content {
+		stringifyKeys(child)
+	}
+}
+
+// propertyOrders indexes the key order of every properties mapping under
+// node by its sorted set of keys
+func propertyOrders(node *yaml.Node) map[string][]string {
+	orders := make(map[string][]string)
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if properties := mappingValue(node, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+			keys := mappingKeys(properties)
+			if id := keySetID(keys); orders[id] == nil {
+				orders[id] = keys
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return orders
+}
+
+// restorePropertyOrder reorders every properties mapping under node whose
+// key set appears in orders
+func restorePropertyOrder(node *yaml.Node, orders map[string][]string) {
+	if properties := mappingValue(node, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+		if order, ok := orders[keySetID(mappingKeys(properties))]; ok {
+			position := make(map[string]int, len(order))
+			for i, key := range order {
+				position[key] = i
+			}
+
+			pairs := make([][2]*yaml.Node, 0, len(properties.Content)/2)
+			for i := 0; i+1 < len(properties.Content); i += 2 {
+				pairs = append(pairs, [2]*yaml.Node{properties.Content[i], properties.Content[i+1]})
+			}
+			sort.SliceStable(pairs, func(i, j int) bool {
+				return position[pairs[i][0].Value] < position[pairs[j][0].Value]
+			})
+
+			properties.Content = properties.Content[:0]
+			for _, pair := range pairs {
+				properties.Content = append(properties.Content, pair[0], pair[1])
+			}
+		}
+	}
+	for _, child := range node.Content {
+		restorePropertyOrder(child, orders)
+	}
+}
+
+// mappingKeys returns the keys of a mapping node in order
+func mappingKeys(node *yaml.Node) []string {
+	keys := make([]string, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// keySetID identifies a set of keys regardless of their order
+func keySetID(keys []string) string {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\x00")
+}
@@ -0,0 +1,191 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const swagger2Spec = `
+swagger: "2.0"
+info:
+  title: Petstore
+  version: "1.0"
+host: api.example.com
+basePath: /v1
+consumes: [application/json]
+produces: [application/json]
+securityDefinitions:
+  api_key:
+    type: apiKey
+    name: X-API-Key
+    in: header
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      parameters:
+        - in: body
+          name: body
+          required: true
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        201:
+          description: Created
+          schema:
+            $ref: '#/definitions/Pet'
+  /pets/{id}/photo:
+    post:
+      operationId: uploadPhoto
+      consumes: [multipart/form-data]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+        - name: file
+          in: formData
+          type: file
+      responses:
+        204:
+          description: Uploaded
+definitions:
+  Pet:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+      id:
+        type: integer
+        format: int64
+`
+
+func TestConvertSpecFromSwagger2(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "swagger.yaml")
+	if err := os.WriteFile(specPath, []byte(swagger2Spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	converted, err := ConvertSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(converted)
+
+	for _, want := range []string{
+		"openapi: 3.0.3",
+		"url: https://api.example.com/v1",
+		"$ref: '#/components/schemas/Pet'",
+		"application/json:",
+		"multipart/form-data:",
+		"api_key:",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("converted document missing %q:\n%s", want, doc)
+		}
+	}
+	if strings.Index(doc, "name:") > strings.Index(doc, "id:") {
+		t.Error("converted document does not keep the property order of the definition")
+	}
+}
+
+func TestGenerateFromSwagger2(t *testing.T) {
+	files := generateFromSpec(t, swagger2Spec, &Config{GenerateModels: true, GenerateClient: true})
+
+	if !strings.Contains(files["models.go"], "type Pet struct {\n\tName string `json:\"name\"`\n\tID   *int64 `json:\"id,omitempty\"`\n}") {
+		t.Errorf("models.go does not contain the converted Pet definition:\n%s", files["models.go"])
+	}
+	if !strings.Contains(files["client.go"], "func (c *Client) CreatePet(ctx context.Context, req Pet) (*Pet, error) {") {
+		t.Errorf("client.go does not contain the CreatePet operation:\n%s", files["client.go"])
+	}
+}
+
+const swagger2CollectionSpec = `
+swagger: "2.0"
+info:
+  title: Search
+  version: "1.0"
+parameters:
+  fields:
+    name: fields
+    in: query
+    type: array
+    collectionFormat: pipes
+    items:
+      type: string
+paths:
+  /pets:
+    parameters:
+      - name: ids
+        in: query
+        type: array
+        items:
+          type: integer
+    get:
+      operationId: findPets
+      parameters:
+        - $ref: '#/parameters/fields'
+        - name: tags
+          in: query
+          type: array
+          collectionFormat: multi
+          items:
+            type: string
+        - name: words
+          in: query
+          type: array
+          collectionFormat: ssv
+          items:
+            type: string
+        - name: cols
+          in: query
+          type: array
+          collectionFormat: tsv
+          items:
+            type: string
+      responses:
+        204:
+          description: OK
+    post:
+      operationId: createPets
+      consumes: [application/x-www-form-urlencoded]
+      parameters:
+        - name: names
+          in: formData
+          type: array
+          collectionFormat: multi
+          items:
+            type: string
+        - name: colors
+          in: formData
+          type: array
+          items:
+            type: string
+      responses:
+        204:
+          description: OK
+`
+
+func TestSwagger2CollectionFormats(t *testing.T) {
+	files := generateFromSpec(t, swagger2CollectionSpec, &Config{GenerateModels: true, GenerateClient: true})
+	clientStr := files["client.go"]
+
+	for _, want := range []string{
+		// csv is the default collectionFormat
+		`client.QueryParam("ids", params.Ids, client.StyleForm, false)`,
+		`client.QueryParam("fields", params.Fields, client.StylePipeDelimited, false)`,
+		`client.QueryParam("tags", params.Tags, client.StyleForm, true)`,
+		`client.QueryParam("words", params.Words, client.StyleSpaceDelimited, false)`,
+		// tsv has no OpenAPI 3 equivalent
+		`client.QueryParam("cols", params.Cols, client.StyleForm, true)`,
+		`client.WithPartEncoding("colors", client.PartEncoding{Style: client.StyleForm, Explode: false})`,
+		`client.WithPartEncoding("names", client.PartEncoding{Style: client.StyleForm, Explode: true})`,
+	} {
+		if !strings.Contains(clientStr, want) {
+			t.Errorf("client.go missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// structValidation returns the body of a struct model's Validate method,
+// recording violations in a client.ValidationErrors named errs
+func (g *Generator) structValidation(model Model) string {
+	var code strings.Builder
+
+	for _, field := range model.Fields {
+		expr := "m." + field.Name
+		goType := field.Type
+		if needsPointer(field) {
+			goType = "*" + goType
+		}
+		path := strconv.Quote(field.JSONName)
+
+		// Only types with a nil value can tell a missing required field apart
+		if field.Required && !field.Nullable && isNillableType(goType) {
+			fmt.Fprintf(&code, "if %s == nil {\nerrs.Add(%s, \"is required\")\n}\n", expr, path)
+		}
+
+		// Optional and nullable fields may be nil, which their length constraints allow
+		optional := !field.Required || field.Nullable
+		code.WriteString(g.valueValidation(expr, goType, field.schema, path, optional, 0))
+	}
+
+	return code.String()
+}
+
+// valueValidation returns the statements validating the Go expression expr,
+// of type goType, against the constraints of schema. Violations are recorded
+// at the JSON path produced by the Go expression path. Optional slices and
+// maps are only checked when they are set.
+func (g *Generator) valueValidation(expr, goType string, schema *openapi3.Schema, path string, optional bool, depth int) string {
+	if strings.HasPrefix(goType, "*") {
+		inner := g.valueValidation("*"+expr, goType[1:], schema, path, false, depth)
+		if inner == "" {
+			return ""
+		}
+		return fmt.Sprintf("if %s != nil {\n%s}\n", expr, inner)
+	}
+
+	if isModelType(goType) {
+		// Named models validate their own constraints; pointers to them have the method too
+		return fmt.Sprintf("if err := %s.Validate(); err != nil {\nerrs.Nest(%s, err)\n}\n", strings.TrimPrefix(expr, "*"), path)
+	}
+	if schema == nil {
+		return ""
+	}
+
+	var code strings.Builder
+	check := func(cond, message string) {
+		fmt.Fprintf(&code, "if %s {\nerrs.Add(%s, %s)\n}\n", cond, path, strconv.Quote(message))
+	}
+	// minLength checks the minimum length of a slice or map, which nil
+	// satisfies when the value is optional
+	minLength := func(min uint64, message string) {
+		cond := fmt.Sprintf("len(%s) < %d", expr, min)
+		if optional {
+			cond = fmt.Sprintf("%s != nil && %s", expr, cond)
+		}
+		check(cond, fmt.Sprintf(message, min))
+	}
+
+	switch {
+	case strings.HasPrefix(goType, "[]"):
+		if schema.MinItems > 0 {
+			minLength(schema.MinItems, "must have at least %d items")
+		}
+		if schema.MaxItems != nil {
+			check(fmt.Sprintf("len(%s) > %d", expr, *schema.MaxItems), fmt.Sprintf("must have at most %d items", *schema.MaxItems))
+		}
+		if schema.UniqueItems {
+			check(fmt.Sprintf("!client.HasUniqueItems(%s)", expr), "items must be unique")
+		}
+		if schema.Items != nil {
+			index, value := fmt.Sprintf("i%d", depth), fmt.Sprintf("v%d", depth)
+			itemPath := fmt.Sprintf("fmt.Sprintf(\"%%s[%%d]\", %s, %s)", path, index)
+			if inner := g.valueValidation(value, goType[2:], schema.Items.Value, itemPath, false, depth+1); inner != "" {
+				fmt.Fprintf(&code, "for %s, %s := range %s {\n%s}\n", index, value, expr, inner)
+			}
+		}
+
+	case strings.HasPrefix(goType, "map[string]"):
+		if schema.MinProps > 0 {
+			minLength(schema.MinProps, "must have at least %d properties")
+		}
+		if schema.MaxProps != nil {
+			check(fmt.Sprintf("len(%s) > %d", expr, *schema.MaxProps), fmt.Sprintf("must have at most %d properties", *schema.MaxProps))
+		}
+		if valueSchema := schema.AdditionalProperties.Schema; valueSchema != nil {
+			key, value := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+			valuePath := fmt.Sprintf("fmt.Sprintf(\"%%s[%%q]\", %s, %s)", path, key)
+			if inner := g.valueValidation(value, strings.TrimPrefix(goType, "map[string]"), valueSchema.Value, valuePath, false, depth+1); inner != "" {
+				fmt.Fprintf(&code, "for %s, %s := range %s {\n%s}\n", key, value, expr, inner)
+			}
+		}
+
+	case goType == "string":
+		if schema.MinLength > 0 {
+			check(fmt.Sprintf("utf8.RuneCountInString(%s) < %d", expr, schema.MinLength), fmt.Sprintf("must be at least %d characters", schema.MinLength))
+		}
+		if schema.MaxLength != nil {
+			check(fmt.Sprintf("utf8.RuneCountInString(%s) > %d", expr, *schema.MaxLength), fmt.Sprintf("must be at most %d characters", *schema.MaxLength))
+		}
+		if schema.Pattern != "" {
+			if _, err := regexp.Compile(schema.Pattern); err != nil {
+				g.warnf("pattern %q is not supported by Go regular expressions, skipping its validation: %v", schema.Pattern, err)
+			} else {
+				check(fmt.Sprintf("!client.MatchPattern(%s, %s)", strconv.Quote(schema.Pattern), expr), "must match pattern "+schema.Pattern)
+			}
+		}
+
+	case isNumericType(goType):
+		value := fmt.Sprintf("float64(%s)", expr)
+		if schema.Min != nil {
+			op, message := "<", "must be at least "
+			if schema.ExclusiveMin {
+				op, message = "<=", "must be greater than "
+			}
+			limit := formatNumber(*schema.Min)
+			check(fmt.Sprintf("%s %s %s", value, op, limit), message+limit)
+		}
+		if schema.Max != nil {
+			op, message := ">", "must be at most "
+			if schema.ExclusiveMax {
+				op, message = ">=", "must be less than "
+			}
+			limit := formatNumber(*schema.Max)
+			check(fmt.Sprintf("%s %s %s", value, op, limit), message+limit)
+		}
+		if schema.MultipleOf != nil {
+			factor := formatNumber(*schema.MultipleOf)
+			check(fmt.Sprintf("!client.IsMultipleOf(%s, %s)", value, factor), "must be a multiple of "+factor)
+		}
+	}
+
+	return code.String()
+}
+
+// validationImports returns the imports needed by generated Validate methods
+func validationImports(models []Model) []string {
+	var code strings.Builder
+	for _, model := range models {
+		code.WriteString(model.Validation)
+	}
+
+	var imports []string
+	for _, pkg := range []string{"fmt", "unicode/utf8"} {
+		name := pkg[strings.LastIndex(pkg, "/")+1:]
+		if strings.Contains(code.String(), name+".") {
+			imports = append(imports, pkg)
+		}
+	}
+	return imports
+}
+
+// isModelType reports whether a Go type is a generated model, as opposed to
+// a builtin, slice, map or standard library type
+func isModelType(goType string) bool {
+	return !isBuiltinType(goType) && !strings.Contains(goType, ".")
+}
+
+// isNumericType reports whether a Go type is an integer or float type
+func isNumericType(goType string) bool {
+	switch goType {
+	case "int", "int32", "int64", "uint", "uint32", "uint64", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// isNillableType reports whether the zero value of a Go type is nil
+func isNillableType(goType string) bool {
+	return strings.HasPrefix(goType, "*") ||
+		strings.HasPrefix(goType, "[]") ||
+		strings.HasPrefix(goType, "map[") ||
+		goType == "interface{}"
+}
+
+// formatNumber formats a schema number as a Go constant
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,102 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+const validationSpec = `
+openapi: 3.0.0
+info:
+  title: Validation API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, lines]
+      properties:
+        id:
+          type: string
+          minLength: 3
+          maxLength: 10
+          pattern: '^[a-z]+$'
+        quantity:
+          type: integer
+          minimum: 1
+          maximum: 10
+          exclusiveMaximum: true
+          multipleOf: 2
+        tags:
+          type: array
+          uniqueItems: true
+          items:
+            type: string
+            minLength: 1
+        lines:
+          type: array
+          minItems: 1
+          items:
+            $ref: '#/components/schemas/Line'
+        status:
+          $ref: '#/components/schemas/Status'
+        aliases:
+          type: array
+          minItems: 2
+          items:
+            type: string
+        labels:
+          type: object
+          minProperties: 1
+          additionalProperties:
+            type: string
+    Line:
+      type: object
+      properties:
+        note:
+          type: string
+    Status:
+      type: string
+      enum: [open, closed]
+`
+
+func TestGenerateValidation(t *testing.T) {
+	files := generateFromSpec(t, validationSpec, &Config{GenerateModels: true, GenerateValidation: true})
+	models := files["models.go"]
+
+	for _, want := range []string{
+		`"github.com/jmcarbo/oapix/pkg/client"`,
+		`"unicode/utf8"`,
+		"func (m Order) Validate() error {",
+		"var errs client.ValidationErrors",
+		`if utf8.RuneCountInString(m.ID) < 3 {`,
+		`errs.Add("id", "must be at most 10 characters")`,
+		`if !client.MatchPattern("^[a-z]+$", m.ID) {`,
+		"if m.Quantity != nil {",
+		`if float64(*m.Quantity) >= 10 {`,
+		`if !client.IsMultipleOf(float64(*m.Quantity), 2) {`,
+		`if !client.HasUniqueItems(m.Tags) {`,
+		`errs.Add(fmt.Sprintf("%s[%d]", "tags", i0), "must be at least 1 characters")`,
+		`if m.Lines == nil {`,
+		`if len(m.Lines) < 1 {`,
+		// Optional arrays and maps may be left unset
+		`if m.Aliases != nil && len(m.Aliases) < 2 {`,
+		`if m.Labels != nil && len(m.Labels) < 1 {`,
+		`errs.Nest(fmt.Sprintf("%s[%d]", "lines", i0), err)`,
+		`if err := m.Status.Validate(); err != nil {`,
+		"func (Line) Validate() error {\n\treturn nil\n}",
+		"func (e Status) Validate() error {",
+	} {
+		if !strings.Contains(models, want) {
+			t.Errorf("models.go missing %q", want)
+		}
+	}
+}
+
+func TestGenerateWithoutValidation(t *testing.T) {
+	files := generateFromSpec(t, validationSpec, &Config{GenerateModels: true})
+	if strings.Contains(files["models.go"], "Validate()") {
+		t.Error("Validate methods generated without GenerateValidation")
+	}
+}